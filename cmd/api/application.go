@@ -14,15 +14,18 @@ import (
 
 	"github.com/svetoslaven/tasktracker/internal/jsonlog"
 	"github.com/svetoslaven/tasktracker/internal/mailer"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
 	"github.com/svetoslaven/tasktracker/internal/services"
 )
 
 type application struct {
-	cfg      config
-	logger   *jsonlog.Logger
-	services services.ServiceRegistry
-	mailer   mailer.Mailer
-	wg       sync.WaitGroup
+	cfg        config
+	logger     *jsonlog.Logger
+	services   services.ServiceRegistry
+	mailer     mailer.Mailer
+	signer     *pagination.CursorSigner
+	wg         sync.WaitGroup
+	shutdownCh chan struct{}
 }
 
 func (app *application) run() error {
@@ -36,6 +39,7 @@ func (app *application) run() error {
 	}
 
 	shutdownErrorCh := make(chan error)
+	app.shutdownCh = make(chan struct{})
 
 	go func() {
 		quitSignalCh := make(chan os.Signal, 1)
@@ -59,11 +63,17 @@ func (app *application) run() error {
 			"addr": srv.Addr,
 		})
 
+		close(app.shutdownCh)
+
 		app.wg.Wait()
 
 		close(shutdownErrorCh)
 	}()
 
+	app.runPeriodically(app.cfg.trash.purgeInterval, app.purgeExpiredTrash)
+	app.runPeriodically(app.cfg.recurrence.interval, app.generateRecurringTasks)
+	app.runPeriodically(10*time.Minute, app.deleteOrphanedAttachmentBlobs)
+
 	app.logger.LogInfo("starting server", map[string]string{
 		"addr":        srv.Addr,
 		"environment": app.cfg.environment,
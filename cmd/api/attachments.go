@@ -0,0 +1,259 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const multipartOverheadBytes = 1_048_576
+
+func (app *application) handleAttachmentUpload(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, app.cfg.attachments.maxSize+multipartOverheadBytes)
+
+	multipartReader, err := r.MultipartReader()
+	if err != nil {
+		app.sendMalformedMultipartResponse(w, r)
+		return
+	}
+
+	uploader := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, uploader.ID)
+	if !ok {
+		return
+	}
+
+	var maxBytesError *http.MaxBytesError
+
+	for {
+		part, err := multipartReader.NextPart()
+		if err != nil {
+			switch {
+			case errors.Is(err, io.EOF):
+				validator := validator.New()
+				validator.AddError("file", "Must be provided.")
+				app.sendValidationErrorResponse(w, r, validator.Errors)
+			case errors.As(err, &maxBytesError):
+				app.sendAttachmentTooLargeResponse(w, r)
+			default:
+				app.sendMalformedMultipartResponse(w, r)
+			}
+
+			return
+		}
+
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+
+		upload := services.AttachmentUpload{
+			Filename: part.FileName(),
+			Content:  http.MaxBytesReader(w, part, app.cfg.attachments.maxSize),
+		}
+
+		attachment, validator, err := app.services.AttachmentService.CreateAttachment(ctx, upload, task, uploader)
+		if err != nil {
+			switch {
+			case errors.Is(err, services.ErrNoPermission):
+				app.sendForbiddenResponse(w, r, "Only team members can attach files to tasks.")
+			case errors.As(err, &maxBytesError):
+				app.sendAttachmentTooLargeResponse(w, r)
+			case errors.Is(err, io.ErrUnexpectedEOF):
+				app.sendMalformedMultipartResponse(w, r)
+			default:
+				app.sendServerErrorResponse(w, r, err)
+			}
+
+			return
+		}
+		if validator != nil {
+			app.sendValidationErrorResponse(w, r, validator.Errors)
+			return
+		}
+
+		if err := app.sendJSONResponse(w, http.StatusCreated, app.newAttachmentEnvelope(attachment), nil); err != nil {
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+}
+
+func (app *application) handleRetrievalOfAllAttachments(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"created_at",
+		[]string{"created_at", "filename", "size"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	attachments, metadata, err := app.services.AttachmentService.GetAllAttachments(ctx, paginationOpts, task.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"attachments": attachments, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleAttachmentRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	attachment, ok := app.getAttachmentByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newAttachmentEnvelope(attachment), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleAttachmentDownload(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	attachment, ok := app.getAttachmentByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	content, err := app.services.AttachmentService.OpenAttachment(ctx, attachment)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendAttachmentNotFoundResponse)
+		return
+	}
+
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{
+		"filename": attachment.Filename,
+	}))
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+
+	http.ServeContent(w, r, attachment.Filename, attachment.CreatedAt, content)
+}
+
+func (app *application) handleAttachmentDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	attachment, ok := app.getAttachmentByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.AttachmentService.DeleteAttachment(ctx, attachment, task, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendAttachmentNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the uploader or a team admin can delete this attachment.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The attachment has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newAttachmentEnvelope(attachment *models.Attachment) envelope {
+	return envelope{"attachment": attachment}
+}
+
+func (app *application) getAttachmentByPathParam(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+) (*models.Attachment, bool) {
+	attachmentID, err := app.parseInt64PathParam(r, "attachment_id")
+	if err != nil {
+		app.sendAttachmentNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	attachment, err := app.services.AttachmentService.GetAttachmentByID(ctx, attachmentID, taskID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendAttachmentNotFoundResponse)
+		return nil, false
+	}
+
+	return attachment, true
+}
+
+func (app *application) sendAttachmentNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "An attachment with this ID does not exist or it does not belong to this task.")
+}
+
+func (app *application) sendAttachmentTooLargeResponse(w http.ResponseWriter, r *http.Request) {
+	msg := fmt.Sprintf("The file must not be larger than %d bytes.", app.cfg.attachments.maxSize)
+	app.sendErrorResponse(w, r, http.StatusRequestEntityTooLarge, msg)
+}
+
+func (app *application) sendMalformedMultipartResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendErrorResponse(w, r, http.StatusBadRequest, "The body must be valid multipart/form-data.")
+}
@@ -1,6 +1,11 @@
 package main
 
-import "fmt"
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+)
 
 func (app *application) runInBackground(fn func()) {
 	app.wg.Add(1)
@@ -17,3 +22,90 @@ func (app *application) runInBackground(fn func()) {
 		fn()
 	}()
 }
+
+func (app *application) runPeriodically(interval time.Duration, job func(ctx context.Context)) {
+	app.wg.Add(1)
+
+	go func() {
+		defer app.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		go func() {
+			select {
+			case <-app.shutdownCh:
+				cancel()
+			case <-ctx.Done():
+			}
+		}()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			app.runJob(ctx, job)
+
+			select {
+			case <-app.shutdownCh:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+func (app *application) runJob(ctx context.Context, job func(ctx context.Context)) {
+	defer func() {
+		if err := recover(); err != nil {
+			app.logger.LogError(fmt.Errorf("%s", err), nil)
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	job(ctx)
+}
+
+func (app *application) purgeExpiredTrash(ctx context.Context) {
+	purged, err := app.services.TaskService.PurgeExpiredTasks(ctx, app.cfg.trash.retention)
+	if err != nil {
+		app.logger.LogError(err, nil)
+		return
+	}
+
+	if purged > 0 {
+		app.logger.LogInfo("purged expired tasks from trash", map[string]string{
+			"count": strconv.FormatInt(purged, 10),
+		})
+	}
+}
+
+func (app *application) generateRecurringTasks(ctx context.Context) {
+	generated, err := app.services.RecurringTaskService.GenerateOccurrences(ctx, app.cfg.recurrence.lookahead)
+	if err != nil {
+		app.logger.LogError(err, nil)
+		return
+	}
+
+	if generated > 0 {
+		app.logger.LogInfo("generated tasks from recurring tasks", map[string]string{
+			"count": strconv.Itoa(generated),
+		})
+	}
+}
+
+func (app *application) deleteOrphanedAttachmentBlobs(ctx context.Context) {
+	deleted, err := app.services.AttachmentService.DeleteOrphanedBlobs(ctx)
+	if err != nil {
+		app.logger.LogError(err, nil)
+		return
+	}
+
+	if deleted > 0 {
+		app.logger.LogInfo("deleted orphaned attachment blobs", map[string]string{
+			"count": strconv.Itoa(deleted),
+		})
+	}
+}
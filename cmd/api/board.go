@@ -0,0 +1,119 @@
+package main
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleBoardRetrieval(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	var filters models.TaskFilters
+
+	status, priority := app.parseTaskFiltersFromQueryParams(queryParams, &filters, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "rank", []string{"rank"}, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	columns, validator, err := app.services.TaskService.GetBoard(
+		ctx,
+		filters,
+		status,
+		priority,
+		paginationOpts,
+		team.ID,
+	)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	envelope := envelope{"columns": columns}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskMove(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Status       string `json:"status"`
+		AfterTaskID  *int64 `json:"after_task_id"`
+		BeforeTaskID *int64 `json:"before_task_id"`
+		Force        bool   `json:"force"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	oldStatus := task.Status.Name
+
+	newStatus := input.Status
+	if newStatus == "" {
+		newStatus = oldStatus
+	}
+
+	move := services.TaskMove{
+		Status:       input.Status,
+		AfterTaskID:  input.AfterTaskID,
+		BeforeTaskID: input.BeforeTaskID,
+		Force:        input.Force,
+	}
+
+	validator, err := app.services.TaskService.MoveTask(ctx, task, move, updater.ID)
+	if err != nil {
+		app.handleTaskStatusChangeError(w, r, err, oldStatus, newStatus)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	app.sendStatusChangeEmails(task, updater, oldStatus)
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskEnvelope(task), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,223 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleCommentCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Body     string `json:"body"`
+		ParentID *int64 `json:"parent_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	author := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, author.ID)
+	if !ok {
+		return
+	}
+
+	comment, validator, err := app.services.CommentService.CreateComment(ctx, input.Body, input.ParentID, author, task)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can comment on tasks.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	app.sendMentionEmails(nil, comment.Mentions, author, task, comment.Body, true)
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newCommentEnvelope(comment), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllComments(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	var filters models.CommentFilters
+
+	if queryParams.Has("parent_id") {
+		parentID := int64(app.parseIntQueryParam(queryParams, "parent_id", 0, validator))
+		filters.ParentID = &parentID
+	}
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"created_at",
+		[]string{"created_at", "updated_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	comments, metadata, err := app.services.CommentService.GetAllComments(ctx, filters, paginationOpts, task.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"comments": comments, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleCommentPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Body string `json:"body"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	comment, ok := app.getCommentByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	previousMentions := comment.Mentions
+
+	validator, err := app.services.CommentService.UpdateComment(ctx, input.Body, comment, task, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the author can edit this comment.")
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	app.sendMentionEmails(previousMentions, comment.Mentions, updater, task, comment.Body, true)
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newCommentEnvelope(comment), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleCommentDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	comment, ok := app.getCommentByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.CommentService.DeleteComment(ctx, comment, task, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendCommentNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the author or a team admin can delete this comment.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The comment has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newCommentEnvelope(comment *models.Comment) envelope {
+	return envelope{"comment": comment}
+}
+
+func (app *application) getCommentByPathParam(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+) (*models.Comment, bool) {
+	commentID, err := app.parseInt64PathParam(r, "comment_id")
+	if err != nil {
+		app.sendCommentNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	comment, err := app.services.CommentService.GetCommentByID(ctx, commentID, taskID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendCommentNotFoundResponse)
+		return nil, false
+	}
+
+	return comment, true
+}
+
+func (app *application) sendCommentNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A comment with this ID does not exist or it does not belong to this task.")
+}
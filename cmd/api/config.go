@@ -38,6 +38,25 @@ type config struct {
 	cors struct {
 		trustedOrigins []string
 	}
+
+	trash struct {
+		retention     time.Duration
+		purgeInterval time.Duration
+	}
+
+	pagination struct {
+		cursorSecret string
+	}
+
+	recurrence struct {
+		interval  time.Duration
+		lookahead time.Duration
+	}
+
+	attachments struct {
+		dir     string
+		maxSize int64
+	}
 }
 
 func loadConfig() config {
@@ -101,6 +120,52 @@ func loadConfig() config {
 		return nil
 	})
 
+	flag.DurationVar(
+		&cfg.trash.retention,
+		"trash-retention",
+		parseDurationEnv("TRASH_RETENTION", 30*24*time.Hour),
+		"Set how long deleted tasks are kept in the trash before being purged",
+	)
+	flag.DurationVar(
+		&cfg.trash.purgeInterval,
+		"trash-purge-interval",
+		parseDurationEnv("TRASH_PURGE_INTERVAL", 1*time.Hour),
+		"Set how often expired tasks are purged from the trash",
+	)
+
+	flag.StringVar(
+		&cfg.pagination.cursorSecret,
+		"pagination-cursor-secret",
+		os.Getenv("PAGINATION_CURSOR_SECRET"),
+		"Set the secret used to sign pagination cursors",
+	)
+
+	flag.DurationVar(
+		&cfg.recurrence.interval,
+		"recurrence-interval",
+		parseDurationEnv("RECURRENCE_INTERVAL", 5*time.Minute),
+		"Set how often recurring tasks are checked for new occurrences",
+	)
+	flag.DurationVar(
+		&cfg.recurrence.lookahead,
+		"recurrence-lookahead",
+		parseDurationEnv("RECURRENCE_LOOKAHEAD", 7*24*time.Hour),
+		"Set how far ahead occurrences of recurring tasks are generated",
+	)
+
+	flag.StringVar(
+		&cfg.attachments.dir,
+		"attachments-dir",
+		parseStringEnv("ATTACHMENTS_DIR", "./data/attachments"),
+		"Set the directory where task attachments are stored",
+	)
+	flag.Int64Var(
+		&cfg.attachments.maxSize,
+		"attachments-max-size",
+		int64(parseIntEnv("ATTACHMENTS_MAX_SIZE", 10_485_760)),
+		"Set the maximum size of a task attachment in bytes",
+	)
+
 	flag.Parse()
 
 	cfg.environment = strings.ToLower(cfg.environment)
@@ -114,6 +179,11 @@ func loadConfig() config {
 		os.Exit(1)
 	}
 
+	if cfg.trash.purgeInterval <= 0 || cfg.recurrence.interval <= 0 {
+		fmt.Println("Invalid trash purge or recurrence interval: Must be greater than zero.")
+		os.Exit(1)
+	}
+
 	return cfg
 }
 
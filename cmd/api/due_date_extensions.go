@@ -0,0 +1,254 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleExtensionRequest(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		ProposedDue time.Time `json:"proposed_due"`
+		Reason      string    `json:"reason"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	requester := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, requester.ID)
+	if !ok {
+		return
+	}
+
+	extension, validator, err := app.services.ExtensionService.RequestExtension(
+		ctx,
+		input.ProposedDue,
+		input.Reason,
+		task,
+		requester,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the assignee can request a due date extension.")
+		case errors.Is(err, services.ErrTaskStatusConflict):
+			app.sendErrorResponse(w, r, http.StatusConflict, "Extensions can only be requested for tasks that are not finished.")
+		case errors.Is(err, services.ErrPendingExtensionExists):
+			app.sendErrorResponse(w, r, http.StatusConflict, "This task already has a pending extension request.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	data := map[string]any{
+		"taskID":      task.ID,
+		"taskTitle":   task.Title,
+		"requester":   requester.Username,
+		"previousDue": extension.PreviousDue.Format(time.RFC3339),
+		"proposedDue": extension.ProposedDue.Format(time.RFC3339),
+		"reason":      extension.Reason,
+	}
+	app.sendEmail(task.Creator.Email, "extension_requested.tmpl", data)
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newExtensionEnvelope(extension), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleExtensionRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	extensionID, err := app.parseInt64PathParam(r, "extension_id")
+	if err != nil {
+		app.sendExtensionNotFoundResponse(w, r)
+		return
+	}
+
+	extension, ok := app.getExtensionByID(ctx, w, r, extensionID, task.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newExtensionEnvelope(extension), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllExtensions(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	var filters models.DueDateExtensionFilters
+
+	if queryParams.Has("status") {
+		status, err := models.NewExtensionStatus(queryParams.Get("status"))
+		if err != nil {
+			validator.AddError("status", "Must be one of pending, approved or rejected.")
+		} else {
+			filters.Status = &status
+		}
+	}
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"created_at",
+		[]string{"created_at", "proposed_due", "decided_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	extensions, metadata, err := app.services.ExtensionService.GetAllExtensions(ctx, filters, paginationOpts, task.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"extensions": extensions, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleExtensionApproval(w http.ResponseWriter, r *http.Request) {
+	app.decideExtension(w, r, app.services.ExtensionService.ApproveExtension)
+}
+
+func (app *application) handleExtensionRejection(w http.ResponseWriter, r *http.Request) {
+	app.decideExtension(w, r, app.services.ExtensionService.RejectExtension)
+}
+
+func (app *application) decideExtension(
+	w http.ResponseWriter,
+	r *http.Request,
+	decide func(
+		ctx context.Context,
+		note string,
+		extension *models.DueDateExtension,
+		task *models.Task,
+		decider *models.User,
+	) (*validator.Validator, error),
+) {
+	var input struct {
+		ExtensionID int64  `json:"extension_id"`
+		Note        string `json:"note"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	decider := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, decider.ID)
+	if !ok {
+		return
+	}
+
+	extension, ok := app.getExtensionByID(ctx, w, r, input.ExtensionID, task.ID)
+	if !ok {
+		return
+	}
+
+	validator, err := decide(ctx, input.Note, extension, task, decider)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the task creator or a team leader can decide on extension requests.")
+		case errors.Is(err, services.ErrExtensionAlreadyDecided):
+			app.sendErrorResponse(w, r, http.StatusConflict, "This extension request has already been decided.")
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	data := map[string]any{
+		"taskID":      task.ID,
+		"taskTitle":   task.Title,
+		"decider":     decider.Username,
+		"status":      extension.Status.String(),
+		"proposedDue": extension.ProposedDue.Format(time.RFC3339),
+		"due":         task.Due.Format(time.RFC3339),
+		"note":        extension.DecisionNote,
+	}
+	app.sendEmail(extension.Requester.Email, "extension_decided.tmpl", data)
+
+	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getExtensionByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	extensionID, taskID int64,
+) (*models.DueDateExtension, bool) {
+	extension, err := app.services.ExtensionService.GetExtensionByID(ctx, extensionID, taskID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendExtensionNotFoundResponse)
+		return nil, false
+	}
+
+	return extension, true
+}
+
+func (app *application) sendExtensionNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "An extension request with this ID does not exist or it does not belong to this task.")
+}
+
+func (app *application) newExtensionEnvelope(extension *models.DueDateExtension) envelope {
+	return envelope{"extension": extension}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
 	"github.com/svetoslaven/tasktracker/internal/services"
 	"github.com/svetoslaven/tasktracker/internal/validator"
 )
@@ -89,7 +90,14 @@ func (app *application) handleRetrievalOfAllInvitations(w http.ResponseWriter, r
 		return
 	}
 
-	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "", []string{""}, validator)
+	paginationOpts := app.parseKeysetPaginationOptsFromQueryParams(
+		queryParams,
+		pagination.NewCursorScope(r.URL.Path, filters),
+		"",
+		[]string{""},
+		1,
+		validator,
+	)
 
 	if validator.HasErrors() {
 		app.sendValidationErrorResponse(w, r, validator.Errors)
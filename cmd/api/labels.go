@@ -0,0 +1,214 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleLabelCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name  string `json:"name"`
+		Color string `json:"color"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	label, validator, err := app.services.LabelService.CreateLabel(ctx, input.Name, input.Color, team.ID, creator.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage labels in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newLabelEnvelope(label), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleLabelRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	label, ok := app.getLabelByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newLabelEnvelope(label), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllLabels(w http.ResponseWriter, r *http.Request) {
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		r.URL.Query(),
+		"name",
+		[]string{"name", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	labels, metadata, err := app.services.LabelService.GetAllLabels(ctx, team.ID, paginationOpts)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"labels": labels, "metadata": metadata}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleLabelPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name  *string `json:"name"`
+		Color *string `json:"color"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	label, ok := app.getLabelByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	validator, err := app.services.LabelService.UpdateLabel(ctx, input.Name, input.Color, label, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage labels in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newLabelEnvelope(label), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleLabelDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	label, ok := app.getLabelByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.LabelService.DeleteLabel(ctx, label, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendLabelNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage labels in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The label has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newLabelEnvelope(label *models.Label) envelope {
+	return envelope{"label": label}
+}
+
+func (app *application) getLabelByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.Label, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	labelID, err := app.parseInt64PathParam(r, "label_id")
+	if err != nil {
+		app.sendLabelNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	label, err := app.services.LabelService.GetLabelByID(ctx, labelID, team.ID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendLabelNotFoundResponse)
+		return nil, false
+	}
+
+	return label, true
+}
+
+func (app *application) sendLabelNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A label with this ID does not exist in this team.")
+}
@@ -1,10 +1,13 @@
 package main
 
 import (
+	"crypto/rand"
 	"os"
 
+	"github.com/svetoslaven/tasktracker/internal/blobstore"
 	"github.com/svetoslaven/tasktracker/internal/jsonlog"
 	"github.com/svetoslaven/tasktracker/internal/mailer"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
 	"github.com/svetoslaven/tasktracker/internal/repositories/postgres"
 	"github.com/svetoslaven/tasktracker/internal/services/domain"
 )
@@ -25,11 +28,29 @@ func main() {
 
 	logger.LogInfo("database connection pool established", nil)
 
+	cursorSecret := []byte(cfg.pagination.cursorSecret)
+
+	if len(cursorSecret) == 0 {
+		cursorSecret = make([]byte, 32)
+
+		if _, err := rand.Read(cursorSecret); err != nil {
+			logger.LogFatal(err, nil)
+		}
+
+		logger.LogInfo("no pagination cursor secret configured, cursors will not survive a restart", nil)
+	}
+
+	blobStore, err := blobstore.NewFileSystemStore(cfg.attachments.dir)
+	if err != nil {
+		logger.LogFatal(err, nil)
+	}
+
 	app := &application{
 		cfg:      cfg,
 		logger:   logger,
-		services: domain.NewServiceRegistry(postgres.NewRepositoryRegistry(db)),
+		services: domain.NewServiceRegistry(postgres.NewRepositoryRegistry(db), blobStore),
 		mailer:   mailer.New(cfg.smtp.host, cfg.smtp.port, cfg.smtp.username, cfg.smtp.password, cfg.smtp.sender),
+		signer:   pagination.NewCursorSigner(cursorSecret),
 	}
 
 	if err := app.run(); err != nil {
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleMilestoneCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name     string    `json:"name"`
+		StartsAt time.Time `json:"starts_at"`
+		EndsAt   time.Time `json:"ends_at"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	milestone, validator, err := app.services.MilestoneService.CreateMilestone(
+		ctx,
+		input.Name,
+		input.StartsAt,
+		input.EndsAt,
+		team.ID,
+		creator.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage milestones in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newMilestoneEnvelope(milestone), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleMilestoneRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	milestone, ok := app.getMilestoneByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMilestoneEnvelope(milestone), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllMilestones(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	states := app.parseCSVQueryParam(queryParams, "states", []string{})
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"starts_at",
+		[]string{"starts_at", "ends_at", "name", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	milestones, metadata, validator, err := app.services.MilestoneService.GetAllMilestones(
+		ctx,
+		states,
+		paginationOpts,
+		team.ID,
+	)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"milestones": milestones, "metadata": metadata}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleMilestonePartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name     *string    `json:"name"`
+		StartsAt *time.Time `json:"starts_at"`
+		EndsAt   *time.Time `json:"ends_at"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	milestone, ok := app.getMilestoneByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	update := services.MilestoneUpdate{
+		Name:     input.Name,
+		StartsAt: input.StartsAt,
+		EndsAt:   input.EndsAt,
+	}
+
+	validator, err := app.services.MilestoneService.UpdateMilestone(ctx, update, milestone, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage milestones in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMilestoneEnvelope(milestone), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleMilestoneClosing(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		MilestoneID     int64  `json:"milestone_id"`
+		NextMilestoneID *int64 `json:"next_milestone_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	closer := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), closer.ID)
+	if !ok {
+		return
+	}
+
+	milestone, ok := app.getMilestoneByID(ctx, w, r, input.MilestoneID, team.ID)
+	if !ok {
+		return
+	}
+
+	movedTasks, validator, err := app.services.MilestoneService.CloseMilestone(
+		ctx,
+		milestone,
+		input.NextMilestoneID,
+		closer.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage milestones in this team.")
+		case errors.Is(err, services.ErrMilestoneClosed):
+			app.sendErrorResponse(w, r, http.StatusConflict, "This milestone has already been closed.")
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	milestone, ok = app.getMilestoneByID(ctx, w, r, milestone.ID, team.ID)
+	if !ok {
+		return
+	}
+
+	envelope := envelope{"milestone": milestone, "moved_tasks": movedTasks}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleMilestoneDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	milestone, ok := app.getMilestoneByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.MilestoneService.DeleteMilestone(ctx, milestone, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendMilestoneNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage milestones in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The milestone has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newMilestoneEnvelope(milestone *models.Milestone) envelope {
+	return envelope{"milestone": milestone}
+}
+
+func (app *application) getMilestoneByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.Milestone, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	milestoneID, err := app.parseInt64PathParam(r, "milestone_id")
+	if err != nil {
+		app.sendMilestoneNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return app.getMilestoneByID(ctx, w, r, milestoneID, team.ID)
+}
+
+func (app *application) getMilestoneByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	milestoneID, teamID int64,
+) (*models.Milestone, bool) {
+	milestone, err := app.services.MilestoneService.GetMilestoneByID(ctx, milestoneID, teamID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendMilestoneNotFoundResponse)
+		return nil, false
+	}
+
+	return milestone, true
+}
+
+func (app *application) sendMilestoneNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A milestone with this ID does not exist in this team.")
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleRetrievalOfAllNotifications(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	var filters models.NotificationFilters
+
+	if queryParams.Has("is_read") {
+		isRead := app.parseBoolQueryParam(queryParams, "is_read", false, validator)
+		filters.IsRead = &isRead
+	}
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"-created_at",
+		[]string{"created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	recipient := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	notifications, metadata, err := app.services.NotificationService.GetAllNotifications(
+		ctx,
+		filters,
+		paginationOpts,
+		recipient.ID,
+	)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"notifications": notifications, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleNotificationReading(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		NotificationID int64 `json:"notification_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	recipient := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	notification, err := app.services.NotificationService.GetNotificationByID(ctx, input.NotificationID, recipient.ID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendNotificationNotFoundResponse)
+		return
+	}
+
+	if err := app.services.NotificationService.MarkNotificationAsRead(ctx, notification); err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) sendMentionEmails(
+	previousMentions, mentions []*models.User,
+	author *models.User,
+	task *models.Task,
+	text string,
+	isComment bool,
+) {
+	for _, mentioned := range mentions {
+		if mentioned.ID == author.ID {
+			continue
+		}
+
+		if slices.ContainsFunc(previousMentions, func(user *models.User) bool { return user.ID == mentioned.ID }) {
+			continue
+		}
+
+		data := map[string]any{
+			"taskID":    task.ID,
+			"taskTitle": task.Title,
+			"author":    author.Username,
+			"text":      text,
+			"isComment": isComment,
+		}
+		app.sendEmail(mentioned.Email, "mention.tmpl", data)
+	}
+}
+
+func (app *application) sendNotificationNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A notification with this ID does not exist.")
+}
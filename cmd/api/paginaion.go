@@ -12,17 +12,56 @@ func (app *application) parsePaginationOptsFromQueryParams(
 	defaultSort string,
 	sortSafelist []string,
 	validator *validator.Validator,
+) pagination.Options {
+	opts := app.parseUnvalidatedPaginationOpts(queryParams, defaultSort, sortSafelist, 1, validator)
+
+	return app.validatePaginationOpts(opts, validator)
+}
+
+func (app *application) parseKeysetPaginationOptsFromQueryParams(
+	queryParams url.Values,
+	cursorScope string,
+	defaultSort string,
+	sortSafelist []string,
+	maxSortKeys int,
+	validator *validator.Validator,
+) pagination.Options {
+	opts := app.parseUnvalidatedPaginationOpts(queryParams, defaultSort, sortSafelist, maxSortKeys, validator)
+
+	if queryParams.Has(pagination.CursorKey) {
+		opts = opts.WithCursor(queryParams.Get(pagination.CursorKey), app.signer, cursorScope)
+	}
+
+	if queryParams.Has(pagination.IncludeTotalKey) {
+		includeTotal := app.parseBoolQueryParam(queryParams, pagination.IncludeTotalKey, opts.IncludeTotal(), validator)
+		opts = opts.WithIncludeTotal(includeTotal)
+	}
+
+	return app.validatePaginationOpts(opts, validator)
+}
+
+func (app *application) parseUnvalidatedPaginationOpts(
+	queryParams url.Values,
+	defaultSort string,
+	sortSafelist []string,
+	maxSortKeys int,
+	validator *validator.Validator,
 ) pagination.Options {
 	page := app.parseIntQueryParam(queryParams, pagination.PageKey, 1, validator)
 	pageSize := app.parseIntQueryParam(queryParams, pagination.PageSizeKey, 20, validator)
 	sort := app.parseStringQueryParam(queryParams, pagination.SortKey, defaultSort)
 
+	return pagination.NewMultiSortOptions(page, pageSize, sort, sortSafelist, maxSortKeys)
+}
+
+func (app *application) validatePaginationOpts(
+	opts pagination.Options,
+	validator *validator.Validator,
+) pagination.Options {
 	if validator.HasErrors() {
 		return pagination.Options{}
 	}
 
-	opts := pagination.NewOptions(page, pageSize, sort, sortSafelist)
-
 	opts.Validate(validator)
 
 	if validator.HasErrors() {
@@ -0,0 +1,246 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleProjectCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name         string `json:"name"`
+		Description  string `json:"description"`
+		LeadUsername string `json:"lead_username"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	project, validator, err := app.services.ProjectService.CreateProject(
+		ctx,
+		input.Name,
+		input.Description,
+		input.LeadUsername,
+		team.ID,
+		creator.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage projects in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newProjectEnvelope(project), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleProjectRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	project, ok := app.getProjectByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newProjectEnvelope(project), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllProjects(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	var filters models.ProjectFilters
+
+	filters.IsArchived = app.parseBoolQueryParam(queryParams, "is_archived", false, validator)
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"name",
+		[]string{"name", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	projects, metadata, err := app.services.ProjectService.GetAllProjects(ctx, filters, paginationOpts, team.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"projects": projects, "metadata": metadata}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleProjectPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name         *string `json:"name"`
+		Description  *string `json:"description"`
+		LeadUsername *string `json:"lead_username"`
+		IsArchived   *bool   `json:"is_archived"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	project, ok := app.getProjectByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	update := services.ProjectUpdate{
+		Name:         input.Name,
+		Description:  input.Description,
+		LeadUsername: input.LeadUsername,
+		IsArchived:   input.IsArchived,
+	}
+
+	validator, err := app.services.ProjectService.UpdateProject(ctx, update, project, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage projects in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newProjectEnvelope(project), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleProjectDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	project, ok := app.getProjectByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.ProjectService.DeleteProject(ctx, project, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendProjectNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage projects in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The project has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newProjectEnvelope(project *models.Project) envelope {
+	return envelope{"project": project}
+}
+
+func (app *application) getProjectByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.Project, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	projectID, err := app.parseInt64PathParam(r, "project_id")
+	if err != nil {
+		app.sendProjectNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return app.getProjectByID(ctx, w, r, projectID, team.ID)
+}
+
+func (app *application) getProjectByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	projectID, teamID int64,
+) (*models.Project, bool) {
+	project, err := app.services.ProjectService.GetProjectByID(ctx, projectID, teamID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendProjectNotFoundResponse)
+		return nil, false
+	}
+
+	return project, true
+}
+
+func (app *application) sendProjectNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A project with this ID does not exist in this team.")
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleRecurringTaskCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Title             string    `json:"title"`
+		Description       string    `json:"description"`
+		Priority          string    `json:"priority"`
+		Labels            []string  `json:"labels"`
+		RRule             string    `json:"rrule"`
+		StartsAt          time.Time `json:"starts_at"`
+		AssigneeUsernames []string  `json:"assignee_usernames"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	assignees, ok := app.getTeamMembersByUsernames(ctx, w, r, input.AssigneeUsernames, team.ID)
+	if !ok {
+		return
+	}
+
+	recurringTask, validator, err := app.services.RecurringTaskService.CreateRecurringTask(
+		ctx,
+		input.Title,
+		input.Description,
+		input.Priority,
+		input.Labels,
+		input.RRule,
+		input.StartsAt,
+		creator,
+		assignees,
+		team.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage recurring tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newRecurringTaskEnvelope(recurringTask), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRecurringTaskRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	recurringTask, ok := app.getRecurringTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newRecurringTaskEnvelope(recurringTask), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllRecurringTasks(w http.ResponseWriter, r *http.Request) {
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		r.URL.Query(),
+		"id",
+		[]string{"id", "created_at", "title", "next_occurrence_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	recurringTasks, metadata, err := app.services.RecurringTaskService.GetAllRecurringTasks(ctx, team.ID, paginationOpts)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"recurring_tasks": recurringTasks, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRecurringTaskPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Title             *string    `json:"title"`
+		Description       *string    `json:"description"`
+		Priority          *string    `json:"priority"`
+		Labels            *[]string  `json:"labels"`
+		RRule             *string    `json:"rrule"`
+		StartsAt          *time.Time `json:"starts_at"`
+		AssigneeUsernames *[]string  `json:"assignee_usernames"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	recurringTask, ok := app.getRecurringTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	update := services.RecurringTaskUpdate{
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    input.Priority,
+		Labels:      input.Labels,
+		RRule:       input.RRule,
+		StartsAt:    input.StartsAt,
+	}
+
+	if input.AssigneeUsernames != nil {
+		assignees, ok := app.getTeamMembersByUsernames(ctx, w, r, *input.AssigneeUsernames, recurringTask.TeamID)
+		if !ok {
+			return
+		}
+
+		update.Assignees = &assignees
+	}
+
+	validator, err := app.services.RecurringTaskService.UpdateRecurringTask(ctx, update, recurringTask, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage recurring tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newRecurringTaskEnvelope(recurringTask), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRecurringTaskPausing(w http.ResponseWriter, r *http.Request) {
+	app.updateRecurringTaskPausedState(w, r, app.services.RecurringTaskService.PauseRecurringTask)
+}
+
+func (app *application) handleRecurringTaskResuming(w http.ResponseWriter, r *http.Request) {
+	app.updateRecurringTaskPausedState(w, r, app.services.RecurringTaskService.ResumeRecurringTask)
+}
+
+func (app *application) handleRecurringTaskOccurrencesPreview(w http.ResponseWriter, r *http.Request) {
+	validator := validator.New()
+
+	count := app.parseIntQueryParam(r.URL.Query(), "count", 10, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	recurringTask, ok := app.getRecurringTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	occurrences, validator, err := app.services.RecurringTaskService.PreviewOccurrences(ctx, recurringTask, count)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"occurrences": occurrences}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRecurringTaskDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	recurringTask, ok := app.getRecurringTaskByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.RecurringTaskService.DeleteRecurringTask(ctx, recurringTask, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendRecurringTaskNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage recurring tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The recurring task has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) updateRecurringTaskPausedState(
+	w http.ResponseWriter,
+	r *http.Request,
+	update func(ctx context.Context, recurringTask *models.RecurringTask, updaterID int64) error,
+) {
+	var input struct {
+		RecurringTaskID int64 `json:"recurring_task_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
+	if !ok {
+		return
+	}
+
+	recurringTask, ok := app.getRecurringTaskByID(ctx, w, r, input.RecurringTaskID, team.ID)
+	if !ok {
+		return
+	}
+
+	if err := update(ctx, recurringTask, updater.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage recurring tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getTeamMembersByUsernames(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	usernames []string,
+	teamID int64,
+) ([]*models.User, bool) {
+	members := make([]*models.User, 0, len(usernames))
+
+	for _, username := range usernames {
+		member, ok := app.getUserByUsername(ctx, w, r, username)
+		if !ok {
+			return nil, false
+		}
+
+		isMember, err := app.services.TeamService.IsMember(ctx, teamID, member.ID)
+		if err != nil {
+			app.sendServerErrorResponse(w, r, err)
+			return nil, false
+		}
+
+		if !isMember {
+			app.sendForbiddenResponse(w, r, fmt.Sprintf("The user %q is not a member of this team.", username))
+			return nil, false
+		}
+
+		members = append(members, member)
+	}
+
+	return members, true
+}
+
+func (app *application) newRecurringTaskEnvelope(recurringTask *models.RecurringTask) envelope {
+	return envelope{"recurring_task": recurringTask}
+}
+
+func (app *application) getRecurringTaskByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	recurringTaskID, teamID int64,
+) (*models.RecurringTask, bool) {
+	recurringTask, err := app.services.RecurringTaskService.GetRecurringTaskByID(ctx, recurringTaskID, teamID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendRecurringTaskNotFoundResponse)
+		return nil, false
+	}
+
+	return recurringTask, true
+}
+
+func (app *application) getRecurringTaskByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.RecurringTask, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	recurringTaskID, err := app.parseInt64PathParam(r, "recurring_task_id")
+	if err != nil {
+		app.sendRecurringTaskNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return app.getRecurringTaskByID(ctx, w, r, recurringTaskID, team.ID)
+}
+
+func (app *application) sendRecurringTaskNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A recurring task with this ID does not exist in this team.")
+}
@@ -96,6 +96,27 @@ func (app *application) parseCSVQueryParam(queryParams url.Values, key string, f
 	return strings.Split(csv, ",")
 }
 
+func (app *application) parseFloat64QueryParam(
+	queryParams url.Values,
+	key string,
+	fallback float64,
+	validator *validator.Validator,
+) float64 {
+	value := queryParams.Get(key)
+
+	if value == "" {
+		return fallback
+	}
+
+	float64Value, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		validator.AddError(key, "Must be a number.")
+		return fallback
+	}
+
+	return float64Value
+}
+
 func (app *application) parseTimeQueryParam(
 	queryParams url.Values,
 	key string,
@@ -117,6 +138,30 @@ func (app *application) parseTimeQueryParam(
 	return timeValue
 }
 
+func (app *application) parseExpectedVersion(
+	r *http.Request,
+	bodyVersion *int,
+	validator *validator.Validator,
+) int {
+	if bodyVersion != nil {
+		return *bodyVersion
+	}
+
+	header := r.Header.Get("If-Match")
+	if header == "" {
+		validator.AddError("version", "Must be provided in the request body or in the If-Match header.")
+		return 0
+	}
+
+	version, err := strconv.Atoi(strings.Trim(strings.TrimPrefix(header, "W/"), `"`))
+	if err != nil {
+		validator.AddError("If-Match", "Must be a version number.")
+		return 0
+	}
+
+	return version
+}
+
 func (app *application) parseInt64PathParam(r *http.Request, key string) (int64, error) {
 	intValue, err := strconv.ParseInt(r.PathValue(key), 10, 64)
 	if err != nil {
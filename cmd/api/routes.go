@@ -24,6 +24,8 @@ func (app *application) registerRoutes() http.Handler {
 	mux.HandleFunc("GET /api/v1/teams/{team_name}/members", app.requireVerifiedUser(app.handleRetrievalOfAllTeamMembers))
 	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/members/{member_username}", app.requireVerifiedUser(app.handleMembershipPartialUpdate))
 	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/members/{member_username}", app.requireVerifiedUser(app.handleTeamMemberRemoval))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/workflow", app.requireVerifiedUser(app.handleWorkflowRetrieval))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/workflow", app.requireVerifiedUser(app.handleWorkflowUpdate))
 
 	mux.HandleFunc("POST /api/v1/invitations", app.requireVerifiedUser(app.handleInvitationCreation))
 	mux.HandleFunc("GET /api/v1/invitations", app.requireVerifiedUser(app.handleRetrievalOfAllInvitations))
@@ -34,9 +36,100 @@ func (app *application) registerRoutes() http.Handler {
 	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks", app.requireVerifiedUser(app.handleTaskCreation))
 	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}", app.requireVerifiedUser(app.handleTaskRetrievalByID))
 	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks", app.requireVerifiedUser(app.handleRetrievalOfAllTasks))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/tasks/{task_id}", app.requireVerifiedUser(app.handleTaskPartialUpdate))
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/bulk", app.requireVerifiedUser(app.handleBulkTaskOperation))
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/import", app.requireVerifiedUser(app.handleTaskImport))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/status", app.requireVerifiedUser(app.handleTaskStatusChange))
 	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/in-progress", app.requireVerifiedUser(app.handleTaskStart))
 	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/completed", app.requireVerifiedUser(app.handleTaskCompletion))
 	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/cancelled", app.requireVerifiedUser(app.handleTaskCancellation))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/{task_id}/position", app.requireVerifiedUser(app.handleTaskMove))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}", app.requireVerifiedUser(app.handleTaskDeletion))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/history", app.requireVerifiedUser(app.handleTaskHistoryRetrieval))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/children", app.requireVerifiedUser(app.handleRetrievalOfAllChildTasks))
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/blockers", app.requireVerifiedUser(app.handleTaskBlockerAddition))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}/blockers/{blocker_id}", app.requireVerifiedUser(app.handleTaskBlockerRemoval))
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/watchers", app.requireVerifiedUser(app.handleTaskWatching))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}/watchers", app.requireVerifiedUser(app.handleTaskUnwatching))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/comments", app.requireVerifiedUser(app.handleCommentCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/comments", app.requireVerifiedUser(app.handleRetrievalOfAllComments))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/tasks/{task_id}/comments/{comment_id}", app.requireVerifiedUser(app.handleCommentPartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}/comments/{comment_id}", app.requireVerifiedUser(app.handleCommentDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/attachments", app.requireVerifiedUser(app.handleAttachmentUpload))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/attachments", app.requireVerifiedUser(app.handleRetrievalOfAllAttachments))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/attachments/{attachment_id}", app.requireVerifiedUser(app.handleAttachmentRetrievalByID))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/attachments/{attachment_id}/content", app.requireVerifiedUser(app.handleAttachmentDownload))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}/attachments/{attachment_id}", app.requireVerifiedUser(app.handleAttachmentDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/time-entries", app.requireVerifiedUser(app.handleTimeEntryCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/time-entries", app.requireVerifiedUser(app.handleRetrievalOfAllTimeEntries))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/tasks/{task_id}/time-entries/{time_entry_id}", app.requireVerifiedUser(app.handleTimeEntryPartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/tasks/{task_id}/time-entries/{time_entry_id}", app.requireVerifiedUser(app.handleTimeEntryDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/tasks/{task_id}/extensions", app.requireVerifiedUser(app.handleExtensionRequest))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/extensions", app.requireVerifiedUser(app.handleRetrievalOfAllExtensions))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/tasks/{task_id}/extensions/{extension_id}", app.requireVerifiedUser(app.handleExtensionRetrievalByID))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/{task_id}/extensions/approved", app.requireVerifiedUser(app.handleExtensionApproval))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/tasks/{task_id}/extensions/rejected", app.requireVerifiedUser(app.handleExtensionRejection))
+
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/board", app.requireVerifiedUser(app.handleBoardRetrieval))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/velocity", app.requireVerifiedUser(app.handleVelocityRetrieval))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/time-report", app.requireVerifiedUser(app.handleTimeReportRetrieval))
+
+	mux.HandleFunc("GET /api/v1/timer", app.requireVerifiedUser(app.handleRunningTimerRetrieval))
+	mux.HandleFunc("PUT /api/v1/timer/running", app.requireVerifiedUser(app.handleTimerStart))
+	mux.HandleFunc("PUT /api/v1/timer/stopped", app.requireVerifiedUser(app.handleTimerStop))
+
+	mux.HandleFunc("GET /api/v1/notifications", app.requireVerifiedUser(app.handleRetrievalOfAllNotifications))
+	mux.HandleFunc("PUT /api/v1/notifications/read", app.requireVerifiedUser(app.handleNotificationReading))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/projects", app.requireVerifiedUser(app.handleProjectCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/projects", app.requireVerifiedUser(app.handleRetrievalOfAllProjects))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/projects/{project_id}", app.requireVerifiedUser(app.handleProjectRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/projects/{project_id}", app.requireVerifiedUser(app.handleProjectPartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/projects/{project_id}", app.requireVerifiedUser(app.handleProjectDeletion))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/projects/{project_id}/tasks", app.requireVerifiedUser(app.handleRetrievalOfAllProjectTasks))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/milestones", app.requireVerifiedUser(app.handleMilestoneCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/milestones", app.requireVerifiedUser(app.handleRetrievalOfAllMilestones))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/milestones/{milestone_id}", app.requireVerifiedUser(app.handleMilestoneRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/milestones/{milestone_id}", app.requireVerifiedUser(app.handleMilestonePartialUpdate))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/milestones/closed", app.requireVerifiedUser(app.handleMilestoneClosing))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/milestones/{milestone_id}", app.requireVerifiedUser(app.handleMilestoneDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/labels", app.requireVerifiedUser(app.handleLabelCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/labels", app.requireVerifiedUser(app.handleRetrievalOfAllLabels))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/labels/{label_id}", app.requireVerifiedUser(app.handleLabelRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/labels/{label_id}", app.requireVerifiedUser(app.handleLabelPartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/labels/{label_id}", app.requireVerifiedUser(app.handleLabelDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/task-templates", app.requireVerifiedUser(app.handleTaskTemplateCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/task-templates", app.requireVerifiedUser(app.handleRetrievalOfAllTaskTemplates))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/task-templates/{template_id}", app.requireVerifiedUser(app.handleTaskTemplateRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/task-templates/{template_id}", app.requireVerifiedUser(app.handleTaskTemplatePartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/task-templates/{template_id}", app.requireVerifiedUser(app.handleTaskTemplateDeletion))
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/task-templates/{template_id}/tasks", app.requireVerifiedUser(app.handleTaskCreationFromTemplate))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/recurring-tasks", app.requireVerifiedUser(app.handleRecurringTaskCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/recurring-tasks", app.requireVerifiedUser(app.handleRetrievalOfAllRecurringTasks))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/recurring-tasks/{recurring_task_id}", app.requireVerifiedUser(app.handleRecurringTaskRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/recurring-tasks/{recurring_task_id}", app.requireVerifiedUser(app.handleRecurringTaskPartialUpdate))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/recurring-tasks/paused", app.requireVerifiedUser(app.handleRecurringTaskPausing))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/recurring-tasks/active", app.requireVerifiedUser(app.handleRecurringTaskResuming))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/recurring-tasks/{recurring_task_id}/occurrences", app.requireVerifiedUser(app.handleRecurringTaskOccurrencesPreview))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/recurring-tasks/{recurring_task_id}", app.requireVerifiedUser(app.handleRecurringTaskDeletion))
+
+	mux.HandleFunc("POST /api/v1/teams/{team_name}/views", app.requireVerifiedUser(app.handleTaskViewCreation))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/views", app.requireVerifiedUser(app.handleRetrievalOfAllTaskViews))
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/views/{view_id}", app.requireVerifiedUser(app.handleTaskViewRetrievalByID))
+	mux.HandleFunc("PATCH /api/v1/teams/{team_name}/views/{view_id}", app.requireVerifiedUser(app.handleTaskViewPartialUpdate))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/views/{view_id}", app.requireVerifiedUser(app.handleTaskViewDeletion))
+
+	mux.HandleFunc("GET /api/v1/teams/{team_name}/trash", app.requireVerifiedUser(app.handleRetrievalOfAllTrashedTasks))
+	mux.HandleFunc("PUT /api/v1/teams/{team_name}/trash/restored", app.requireVerifiedUser(app.handleTaskRestoration))
+	mux.HandleFunc("DELETE /api/v1/teams/{team_name}/trash/{task_id}", app.requireVerifiedUser(app.handleTrashedTaskPurge))
 
 	standardMiddlewareChain := app.newMiddlewareChain(
 		app.recoverPanic,
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/services"
+)
+
+func (app *application) handleTaskBlockerAddition(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		BlockerID int64 `json:"blocker_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	validator, err := app.services.TaskService.AddTaskBlocker(ctx, task, input.BlockerID, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage task blockers in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	task, ok = app.getTaskByID(ctx, w, r, task.ID, task.TeamID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskEnvelope(task), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskBlockerRemoval(w http.ResponseWriter, r *http.Request) {
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	blockerID, err := app.parseInt64PathParam(r, "blocker_id")
+	if err != nil {
+		app.sendTaskBlockerNotFoundResponse(w, r)
+		return
+	}
+
+	if err := app.services.TaskService.RemoveTaskBlocker(ctx, task, blockerID, updater.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendTaskBlockerNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage task blockers in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The blocker has been removed successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) sendTaskBlockerNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "This task is not blocked by a task with this ID.")
+}
@@ -0,0 +1,175 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+type bulkTaskResult struct {
+	TaskID int64  `json:"task_id"`
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (app *application) handleBulkTaskOperation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Tasks []struct {
+			ID      int64 `json:"id"`
+			Version *int  `json:"version"`
+		} `json:"tasks"`
+		Action            string   `json:"action"`
+		Status            string   `json:"status"`
+		Priority          string   `json:"priority"`
+		AssigneeUsernames []string `json:"assignee_usernames"`
+		Force             bool     `json:"force"`
+		Mode              string   `json:"mode"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	if input.Mode == "" {
+		input.Mode = "atomic"
+	}
+
+	operation := services.BulkTaskOperation{
+		Status:   input.Status,
+		Priority: input.Priority,
+		Force:    input.Force,
+	}
+
+	validator := validator.New()
+
+	switch input.Action {
+	case "status":
+		operation.Action = services.BulkTaskActionChangeStatus
+	case "reassign":
+		operation.Action = services.BulkTaskActionReassign
+	case "priority":
+		operation.Action = services.BulkTaskActionChangePriority
+	case "delete":
+		operation.Action = services.BulkTaskActionDelete
+	default:
+		validator.AddError("action", "Must be one of status, reassign, priority or delete.")
+	}
+
+	validator.Check(input.Mode == "atomic" || input.Mode == "best_effort", "mode", "Must be atomic or best_effort.")
+
+	items := make([]services.BulkTaskItem, 0, len(input.Tasks))
+
+	for _, task := range input.Tasks {
+		if task.Version == nil {
+			validator.AddError("tasks", "Must provide the version of every task.")
+			break
+		}
+
+		items = append(items, services.BulkTaskItem{TaskID: task.ID, Version: *task.Version})
+	}
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
+	if !ok {
+		return
+	}
+
+	if operation.Action == services.BulkTaskActionReassign {
+		operation.Assignees, ok = app.getTeamMembersByUsernames(ctx, w, r, input.AssigneeUsernames, team.ID)
+		if !ok {
+			return
+		}
+	}
+
+	isAtomic := input.Mode == "atomic"
+
+	results, validator, err := app.services.TaskService.BulkUpdateTasks(
+		ctx,
+		items,
+		operation,
+		isAtomic,
+		team.ID,
+		updater.ID,
+	)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	response := make([]bulkTaskResult, 0, len(results))
+	appliedCount, failedCount := 0, 0
+
+	for _, result := range results {
+		item := bulkTaskResult{TaskID: result.TaskID}
+
+		switch {
+		case result.IsApplied:
+			item.Result = "applied"
+			appliedCount++
+
+			if result.Task != nil {
+				app.sendStatusChangeEmails(result.Task, updater, result.OldStatus)
+			}
+		case result.Err != nil:
+			item.Result = "failed"
+			item.Error = app.getBulkTaskErrorMessage(result.Err, operation)
+			failedCount++
+		default:
+			item.Result = "rolled_back"
+		}
+
+		response = append(response, item)
+	}
+
+	envelope := envelope{
+		"results":     response,
+		"applied":     appliedCount,
+		"failed":      failedCount,
+		"rolled_back": len(results) - appliedCount - failedCount,
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getBulkTaskErrorMessage(err error, operation services.BulkTaskOperation) string {
+	switch {
+	case errors.Is(err, services.ErrNoRecordsFound):
+		return "A task with this ID does not exist or it does not belong to this team."
+	case errors.Is(err, services.ErrNoPermission):
+		return "You do not have permission to perform this action on the task."
+	case errors.Is(err, services.ErrTaskOverdue):
+		return "This task is overdue."
+	case errors.Is(err, services.ErrTaskStatusConflict):
+		return fmt.Sprintf("The team workflow does not allow moving the task to %s from its current status.", operation.Status)
+	case errors.Is(err, services.ErrTaskBlocked):
+		return "This task cannot be started until all tasks blocking it are completed."
+	case errors.Is(err, services.ErrTaskHasOpenSubtasks):
+		return "This task has subtasks that are not completed yet. Set force to true to complete it anyway."
+	case errors.Is(err, services.ErrEditConflict):
+		return "The task has changed since the given version. Reload it and try again."
+	default:
+		app.logger.LogError(err, nil)
+		return "The task could not be updated."
+	}
+}
@@ -0,0 +1,182 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/taskimport"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const maxTaskImportBytes = 5_242_880
+
+type taskImportResult struct {
+	Row    int               `json:"row"`
+	Result string            `json:"result"`
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+func (app *application) handleTaskImport(w http.ResponseWriter, r *http.Request) {
+	format, ok := app.getTaskImportFormat(r)
+	if !ok {
+		msg := "The body must be sent as text/csv or application/json."
+		app.sendErrorResponse(w, r, http.StatusUnsupportedMediaType, msg)
+		return
+	}
+
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	isDryRun := app.parseBoolQueryParam(queryParams, "dry_run", false, validator)
+
+	columns, err := taskimport.ParseMapping(queryParams.Get("columns"))
+	if err != nil {
+		switch {
+		case errors.Is(err, taskimport.ErrUnknownField):
+			validator.AddError("columns", "Must only map columns to known task fields.")
+		default:
+			validator.AddError("columns", "Must be a comma-separated list of column:field pairs.")
+		}
+	}
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, maxTaskImportBytes)
+
+	records, err := taskimport.Parse(r.Body, format, columns)
+	if err != nil {
+		app.handleTaskImportParseError(w, r, err, format)
+		return
+	}
+
+	rows := make([]*services.TaskImportRow, 0, len(records))
+
+	for _, record := range records {
+		rows = append(rows, &services.TaskImportRow{
+			Due:               record["due"],
+			Title:             record["title"],
+			Description:       record["description"],
+			Priority:          record["priority"],
+			AssigneeUsernames: record.List("assignee_usernames"),
+			Labels:            record.List("labels"),
+			Estimate:          record["estimate"],
+			EstimateUnit:      record["estimate_unit"],
+			ParentID:          record["parent_id"],
+			MilestoneID:       record["milestone_id"],
+			ProjectID:         record["project_id"],
+		})
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	results, validator, err := app.services.TaskService.ImportTasks(ctx, rows, isDryRun, creator, team.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to assign tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	response := make([]taskImportResult, 0, len(results))
+	invalidResults := []taskImportResult{}
+	tasks := make([]*models.Task, 0, len(results))
+
+	for _, result := range results {
+		item := taskImportResult{Row: result.Row, Result: "valid"}
+
+		if result.Errors != nil {
+			item.Result = "invalid"
+			item.Errors = result.Errors
+			invalidResults = append(invalidResults, item)
+		} else {
+			tasks = append(tasks, result.Task)
+		}
+
+		response = append(response, item)
+	}
+
+	if isDryRun {
+		envelope := envelope{
+			"results": response,
+			"valid":   len(response) - len(invalidResults),
+			"invalid": len(invalidResults),
+		}
+
+		if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	if len(invalidResults) > 0 {
+		app.sendErrorResponse(w, r, http.StatusUnprocessableEntity, envelope{"rows": invalidResults})
+		return
+	}
+
+	for _, task := range tasks {
+		app.sendMentionEmails(nil, task.Mentions, creator, task, task.Description, false)
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, envelope{"tasks": tasks, "imported": len(tasks)}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) getTaskImportFormat(r *http.Request) (string, bool) {
+	mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if err != nil {
+		return "", false
+	}
+
+	switch mediaType {
+	case "text/csv":
+		return taskimport.FormatCSV, true
+	case "application/json":
+		return taskimport.FormatJSON, true
+	default:
+		return "", false
+	}
+}
+
+func (app *application) handleTaskImportParseError(w http.ResponseWriter, r *http.Request, err error, format string) {
+	var maxBytesError *http.MaxBytesError
+
+	switch {
+	case errors.As(err, &maxBytesError):
+		msg := fmt.Sprintf("The body must not be larger than %d bytes.", maxBytesError.Limit)
+		app.sendErrorResponse(w, r, http.StatusRequestEntityTooLarge, msg)
+	case errors.Is(err, taskimport.ErrMalformedFile) && format == taskimport.FormatCSV:
+		app.sendErrorResponse(w, r, http.StatusBadRequest, "The body must be valid CSV with a header row.")
+	case errors.Is(err, taskimport.ErrMalformedFile):
+		app.sendErrorResponse(w, r, http.StatusBadRequest, "The body must be a JSON array of objects with string, number or string array values.")
+	default:
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
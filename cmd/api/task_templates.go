@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleTaskTemplateCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name        string   `json:"name"`
+		Title       string   `json:"title"`
+		Description string   `json:"description"`
+		Priority    string   `json:"priority"`
+		DueOffset   string   `json:"due_offset"`
+		Labels      []string `json:"labels"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	template, validator, err := app.services.TaskTemplateService.CreateTaskTemplate(
+		ctx,
+		input.Name,
+		input.Title,
+		input.Description,
+		input.Priority,
+		input.DueOffset,
+		input.Labels,
+		team.ID,
+		creator.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage task templates in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newTaskTemplateEnvelope(template), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskTemplateRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	template, ok := app.getTaskTemplateByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskTemplateEnvelope(template), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllTaskTemplates(w http.ResponseWriter, r *http.Request) {
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		r.URL.Query(),
+		"name",
+		[]string{"name", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	templates, metadata, err := app.services.TaskTemplateService.GetAllTaskTemplates(ctx, team.ID, paginationOpts)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"templates": templates, "metadata": metadata}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskTemplatePartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name        *string   `json:"name"`
+		Title       *string   `json:"title"`
+		Description *string   `json:"description"`
+		Priority    *string   `json:"priority"`
+		DueOffset   *string   `json:"due_offset"`
+		Labels      *[]string `json:"labels"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	template, ok := app.getTaskTemplateByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	update := services.TaskTemplateUpdate{
+		Name:        input.Name,
+		Title:       input.Title,
+		Description: input.Description,
+		Priority:    input.Priority,
+		DueOffset:   input.DueOffset,
+		Labels:      input.Labels,
+	}
+
+	validator, err := app.services.TaskTemplateService.UpdateTaskTemplate(ctx, update, template, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage task templates in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskTemplateEnvelope(template), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskTemplateDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	template, ok := app.getTaskTemplateByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TaskTemplateService.DeleteTaskTemplate(ctx, template, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendTaskTemplateNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to manage task templates in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The task template has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskCreationFromTemplate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Values            map[string]string `json:"values"`
+		AssigneeUsernames []string          `json:"assignee_usernames"`
+		ParentID          *int64            `json:"parent_id"`
+		MilestoneID       *int64            `json:"milestone_id"`
+		ProjectID         *int64            `json:"project_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	template, ok := app.getTaskTemplateByPathParams(ctx, w, r, creator.ID)
+	if !ok {
+		return
+	}
+
+	assignees, ok := app.getTeamMembersByUsernames(ctx, w, r, input.AssigneeUsernames, template.TeamID)
+	if !ok {
+		return
+	}
+
+	draft, validator, err := app.services.TaskTemplateService.RenderTaskTemplate(template, input.Values)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	task, validator, err := app.services.TaskService.CreateTask(
+		ctx,
+		draft.Due,
+		draft.Title,
+		draft.Description,
+		draft.Priority,
+		creator,
+		assignees,
+		template.TeamID,
+		input.ParentID,
+		input.MilestoneID,
+		input.ProjectID,
+		draft.Labels,
+		0,
+		"",
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to assign tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	app.sendMentionEmails(nil, task.Mentions, creator, task, task.Description, false)
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newTaskEnvelope(task), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newTaskTemplateEnvelope(template *models.TaskTemplate) envelope {
+	return envelope{"template": template}
+}
+
+func (app *application) getTaskTemplateByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.TaskTemplate, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	templateID, err := app.parseInt64PathParam(r, "template_id")
+	if err != nil {
+		app.sendTaskTemplateNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	template, err := app.services.TaskTemplateService.GetTaskTemplateByID(ctx, templateID, team.ID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendTaskTemplateNotFoundResponse)
+		return nil, false
+	}
+
+	return template, true
+}
+
+func (app *application) sendTaskTemplateNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A task template with this ID does not exist in this team.")
+}
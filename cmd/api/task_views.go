@@ -0,0 +1,273 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleTaskViewCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name     string             `json:"name"`
+		IsShared bool               `json:"is_shared"`
+		Filters  models.TaskFilters `json:"filters"`
+		Sort     string             `json:"sort"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	validator := validator.New()
+
+	app.validateTaskViewSort(input.Sort, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	creator := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), creator.ID)
+	if !ok {
+		return
+	}
+
+	view, validator, err := app.services.TaskViewService.CreateTaskView(
+		ctx,
+		input.Name,
+		input.IsShared,
+		input.Filters,
+		input.Sort,
+		team.ID,
+		creator.ID,
+	)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to create views in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	view.Owner = creator
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newTaskViewEnvelope(view), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskViewRetrievalByID(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	view, ok := app.getTaskViewByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskViewEnvelope(view), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllTaskViews(w http.ResponseWriter, r *http.Request) {
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		r.URL.Query(),
+		"name",
+		[]string{"name", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	views, metadata, err := app.services.TaskViewService.GetAllTaskViews(ctx, team.ID, retriever.ID, paginationOpts)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"views": views, "metadata": metadata}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskViewPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Name     *string             `json:"name"`
+		IsShared *bool               `json:"is_shared"`
+		Filters  *models.TaskFilters `json:"filters"`
+		Sort     *string             `json:"sort"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	if input.Sort != nil {
+		validator := validator.New()
+
+		app.validateTaskViewSort(*input.Sort, validator)
+
+		if validator.HasErrors() {
+			app.sendValidationErrorResponse(w, r, validator.Errors)
+			return
+		}
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	view, ok := app.getTaskViewByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	update := services.TaskViewUpdate{
+		Name:     input.Name,
+		IsShared: input.IsShared,
+		Filters:  input.Filters,
+		Sort:     input.Sort,
+	}
+
+	validator, err := app.services.TaskViewService.UpdateTaskView(ctx, update, view, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the owner of this view can update it.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskViewEnvelope(view), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskViewDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	view, ok := app.getTaskViewByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TaskViewService.DeleteTaskView(ctx, view, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendTaskViewNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to delete this view.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The view has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) validateTaskViewSort(sort string, validator *validator.Validator) {
+	if sort == "" {
+		return
+	}
+
+	pagination.NewMultiSortOptions(1, 1, sort, taskSortSafelist, maxTaskSortKeys).Validate(validator)
+}
+
+func (app *application) newTaskViewEnvelope(view *models.TaskView) envelope {
+	return envelope{"view": view}
+}
+
+func (app *application) getTaskViewByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	viewID, teamID, retrieverID int64,
+) (*models.TaskView, bool) {
+	view, err := app.services.TaskViewService.GetTaskViewByID(ctx, viewID, teamID, retrieverID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendTaskViewNotFoundResponse)
+		return nil, false
+	}
+
+	return view, true
+}
+
+func (app *application) getTaskViewByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.TaskView, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	viewID, err := app.parseInt64PathParam(r, "view_id")
+	if err != nil {
+		app.sendTaskViewNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return app.getTaskViewByID(ctx, w, r, viewID, team.ID, retrieverID)
+}
+
+func (app *application) sendTaskViewNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A view with this ID does not exist in this team.")
+}
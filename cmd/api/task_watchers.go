@@ -0,0 +1,60 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/services"
+)
+
+func (app *application) handleTaskWatching(w http.ResponseWriter, r *http.Request) {
+	watcher := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, watcher.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TaskService.WatchTask(ctx, task, watcher.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can watch tasks.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "You are now watching this task."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskUnwatching(w http.ResponseWriter, r *http.Request) {
+	watcher := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, watcher.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TaskService.UnwatchTask(ctx, task, watcher.ID); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	msg := "You are no longer watching this task."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
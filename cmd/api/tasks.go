@@ -5,20 +5,35 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
 	"github.com/svetoslaven/tasktracker/internal/services"
 	"github.com/svetoslaven/tasktracker/internal/validator"
 )
 
+const maxTaskSortKeys = 4
+
+var taskSortSafelist = []string{"id", "created_at", "due", "title", "status", "priority", "estimate", "rank", "relevance"}
+
 func (app *application) handleTaskCreation(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Due              time.Time `json:"due"`
-		Title            string    `json:"title"`
-		Description      string    `json:"description"`
-		Priority         string    `json:"priority"`
-		AssigneeUsername string    `json:"assignee_username"`
+		Due               time.Time `json:"due"`
+		Title             string    `json:"title"`
+		Description       string    `json:"description"`
+		Priority          string    `json:"priority"`
+		AssigneeUsernames []string  `json:"assignee_usernames"`
+		AssigneeUsername  string    `json:"assignee_username"`
+		ParentID          *int64    `json:"parent_id"`
+		MilestoneID       *int64    `json:"milestone_id"`
+		ProjectID         *int64    `json:"project_id"`
+		Labels            []string  `json:"labels"`
+		Estimate          float64   `json:"estimate"`
+		EstimateUnit      string    `json:"estimate_unit"`
 	}
 
 	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
@@ -26,6 +41,10 @@ func (app *application) handleTaskCreation(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	if input.AssigneeUsernames == nil && input.AssigneeUsername != "" {
+		input.AssigneeUsernames = []string{input.AssigneeUsername}
+	}
+
 	creator := app.getRequestContextUser(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -36,22 +55,11 @@ func (app *application) handleTaskCreation(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	assignee, ok := app.getUserByUsername(ctx, w, r, input.AssigneeUsername)
+	assignees, ok := app.getTeamMembersByUsernames(ctx, w, r, input.AssigneeUsernames, team.ID)
 	if !ok {
 		return
 	}
 
-	isAssigneeMember, err := app.services.TeamService.IsMember(ctx, team.ID, assignee.ID)
-	if err != nil {
-		app.sendServerErrorResponse(w, r, err)
-		return
-	}
-
-	if !isAssigneeMember {
-		app.sendForbiddenResponse(w, r, "The assignee is not a member of this team.")
-		return
-	}
-
 	task, validator, err := app.services.TaskService.CreateTask(
 		ctx,
 		input.Due,
@@ -59,8 +67,14 @@ func (app *application) handleTaskCreation(w http.ResponseWriter, r *http.Reques
 		input.Description,
 		input.Priority,
 		creator,
-		assignee,
+		assignees,
 		team.ID,
+		input.ParentID,
+		input.MilestoneID,
+		input.ProjectID,
+		input.Labels,
+		input.Estimate,
+		input.EstimateUnit,
 	)
 	if err != nil {
 		switch {
@@ -77,6 +91,8 @@ func (app *application) handleTaskCreation(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
+	app.sendMentionEmails(nil, task.Mentions, creator, task, task.Description, false)
+
 	if err := app.sendJSONResponse(w, http.StatusCreated, app.newTaskEnvelope(task), nil); err != nil {
 		app.sendServerErrorResponse(w, r, err)
 	}
@@ -110,70 +126,123 @@ func (app *application) handleTaskRetrievalByID(w http.ResponseWriter, r *http.R
 }
 
 func (app *application) handleRetrievalOfAllTasks(w http.ResponseWriter, r *http.Request) {
-	queryParams := r.URL.Query()
-
-	validator := validator.New()
+	app.sendAllTasksResponse(w, r, false, false, false)
+}
 
-	var filters models.TaskFilters
+func (app *application) handleRetrievalOfAllChildTasks(w http.ResponseWriter, r *http.Request) {
+	app.sendAllTasksResponse(w, r, false, true, false)
+}
 
-	filters.CreatorUsername = app.parseStringQueryParam(queryParams, "creator_username", "")
-	filters.AssigneeUsername = app.parseStringQueryParam(queryParams, "assignee_username", "")
+func (app *application) handleRetrievalOfAllProjectTasks(w http.ResponseWriter, r *http.Request) {
+	app.sendAllTasksResponse(w, r, false, false, true)
+}
 
-	status := app.parseCSVQueryParam(queryParams, "status", []string{})
-	priority := app.parseCSVQueryParam(queryParams, "priority", []string{})
+func (app *application) handleTaskStatusChange(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TaskID int64  `json:"task_id"`
+		Status string `json:"status"`
+		Force  bool   `json:"force"`
+	}
 
-	if queryParams.Has("created_before") {
-		createdBefore := app.parseTimeQueryParam(queryParams, "created_before", time.Time{}, validator)
-		filters.CreatedBefore = &createdBefore
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
 	}
 
-	if queryParams.Has("created_after") {
-		createdAfter := app.parseTimeQueryParam(queryParams, "created_after", time.Time{}, validator)
-		filters.CreatedAfter = &createdAfter
+	app.changeTaskStatus(w, r, input.TaskID, input.Status, input.Force)
+}
+
+func (app *application) handleTaskStart(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TaskID int64 `json:"task_id"`
 	}
 
-	if queryParams.Has("due_before") {
-		dueBefore := app.parseTimeQueryParam(queryParams, "due_before", time.Time{}, validator)
-		filters.DueBefore = &dueBefore
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
 	}
 
-	if queryParams.Has("due_after") {
-		dueAfter := app.parseTimeQueryParam(queryParams, "due_after", time.Time{}, validator)
-		filters.DueAfter = &dueAfter
+	app.changeTaskStatusCategory(w, r, input.TaskID, models.StatusCategoryDoing, false)
+}
+
+func (app *application) handleTaskCompletion(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TaskID int64 `json:"task_id"`
+		Force  bool  `json:"force"`
 	}
 
-	if validator.HasErrors() {
-		app.sendValidationErrorResponse(w, r, validator.Errors)
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
 		return
 	}
 
-	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "", []string{""}, validator)
+	app.changeTaskStatusCategory(w, r, input.TaskID, models.StatusCategoryDone, input.Force)
+}
 
-	if validator.HasErrors() {
-		app.sendValidationErrorResponse(w, r, validator.Errors)
+func (app *application) handleTaskCancellation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TaskID int64 `json:"task_id"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
 		return
 	}
 
-	retriever := app.getRequestContextUser(r)
+	app.changeTaskStatusCategory(w, r, input.TaskID, models.StatusCategoryCancelled, false)
+}
+
+func (app *application) changeTaskStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+	newStatus string,
+	force bool,
+) {
+	app.updateTaskStatus(w, r, taskID, newStatus, func(ctx context.Context, task *models.Task, updaterID int64) (*validator.Validator, error) {
+		return app.services.TaskService.UpdateTaskStatus(ctx, task, newStatus, updaterID, force)
+	})
+}
+
+func (app *application) changeTaskStatusCategory(
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+	category models.StatusCategory,
+	force bool,
+) {
+	app.updateTaskStatus(w, r, taskID, category.String(), func(ctx context.Context, task *models.Task, updaterID int64) (*validator.Validator, error) {
+		return app.services.TaskService.UpdateTaskStatusCategory(ctx, task, category, updaterID, force)
+	})
+}
+
+func (app *application) updateTaskStatus(
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+	newStatus string,
+	update func(ctx context.Context, task *models.Task, updaterID int64) (*validator.Validator, error),
+) {
+	updater := app.getRequestContextUser(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
 	if !ok {
 		return
 	}
 
-	tasks, metadata, validator, err := app.services.TaskService.GetAllTasks(
-		ctx,
-		filters,
-		status,
-		priority,
-		paginationOpts,
-		team.ID,
-	)
+	task, ok := app.getTaskByID(ctx, w, r, taskID, team.ID)
+	if !ok {
+		return
+	}
+
+	oldStatus := task.Status.Name
+
+	validator, err := update(ctx, task, updater.ID)
 	if err != nil {
-		app.sendServerErrorResponse(w, r, err)
+		app.handleTaskStatusChangeError(w, r, err, oldStatus, newStatus)
 		return
 	}
 	if validator != nil {
@@ -181,15 +250,76 @@ func (app *application) handleRetrievalOfAllTasks(w http.ResponseWriter, r *http
 		return
 	}
 
-	envelope := envelope{"tasks": tasks, "metadata": metadata}
-	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+	app.sendStatusChangeEmails(task, updater, oldStatus)
+
+	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
 		app.sendServerErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) handleTaskStart(w http.ResponseWriter, r *http.Request) {
+func (app *application) handleTaskStatusChangeError(
+	w http.ResponseWriter,
+	r *http.Request,
+	err error,
+	oldStatus, newStatus string,
+) {
+	switch {
+	case errors.Is(err, services.ErrNoPermission):
+		msg := fmt.Sprintf("You are not allowed to move this task to the %s status.", newStatus)
+		app.sendForbiddenResponse(w, r, msg)
+	case errors.Is(err, services.ErrTaskOverdue):
+		app.sendOverdueTaskResponse(w, r)
+	case errors.Is(err, services.ErrTaskStatusConflict):
+		msg := fmt.Sprintf("The team workflow does not allow moving a task from %s to %s.", oldStatus, newStatus)
+		app.sendForbiddenResponse(w, r, msg)
+	case errors.Is(err, services.ErrTaskBlocked):
+		app.sendForbiddenResponse(w, r, "This task cannot be started until all tasks blocking it are completed.")
+	case errors.Is(err, services.ErrTaskHasOpenSubtasks):
+		msg := "This task has subtasks that are not completed yet. Set force to true to complete it anyway."
+		app.sendForbiddenResponse(w, r, msg)
+	case errors.Is(err, services.ErrEditConflict):
+		app.sendEditConflictResponse(w, r)
+	default:
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) sendStatusChangeEmails(task *models.Task, updater *models.User, oldStatus string) {
+	if task.Status.Name == oldStatus {
+		return
+	}
+
+	for _, watcher := range task.Watchers {
+		if watcher.ID == updater.ID {
+			continue
+		}
+
+		data := map[string]any{
+			"taskID":    task.ID,
+			"taskTitle": task.Title,
+			"updater":   updater.Username,
+			"oldStatus": oldStatus,
+			"newStatus": task.Status.Name,
+		}
+		app.sendEmail(watcher.Email, "task_status_changed.tmpl", data)
+	}
+}
+
+func (app *application) handleTaskPartialUpdate(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		TaskID int64 `json:"task_id"`
+		Due               *time.Time `json:"due"`
+		Title             *string    `json:"title"`
+		Description       *string    `json:"description"`
+		Priority          *string    `json:"priority"`
+		AssigneeUsernames *[]string  `json:"assignee_usernames"`
+		AssigneeUsername  *string    `json:"assignee_username"`
+		ParentID          *int64     `json:"parent_id"`
+		MilestoneID       *int64     `json:"milestone_id"`
+		ProjectID         *int64     `json:"project_id"`
+		Labels            *[]string  `json:"labels"`
+		Estimate          *float64   `json:"estimate"`
+		EstimateUnit      *string    `json:"estimate_unit"`
+		Version           *int       `json:"version"`
 	}
 
 	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
@@ -197,6 +327,19 @@ func (app *application) handleTaskStart(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if input.AssigneeUsernames == nil && input.AssigneeUsername != nil {
+		input.AssigneeUsernames = &[]string{*input.AssigneeUsername}
+	}
+
+	validator := validator.New()
+
+	expectedVersion := app.parseExpectedVersion(r, input.Version, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
 	updater := app.getRequestContextUser(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
@@ -207,21 +350,47 @@ func (app *application) handleTaskStart(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	task, ok := app.getTaskByID(ctx, w, r, input.TaskID, team.ID)
+	taskID, err := app.parseInt64PathParam(r, "task_id")
+	if err != nil {
+		app.sendTaskNotFoundResponse(w, r)
+		return
+	}
+
+	task, ok := app.getTaskByID(ctx, w, r, taskID, team.ID)
 	if !ok {
 		return
 	}
 
-	err := app.services.TaskService.UpdateTaskStatus(ctx, task, models.TaskStatusInProgress, updater.ID)
+	update := services.TaskUpdate{
+		Due:          input.Due,
+		Title:        input.Title,
+		Description:  input.Description,
+		Priority:     input.Priority,
+		ParentID:     input.ParentID,
+		MilestoneID:  input.MilestoneID,
+		ProjectID:    input.ProjectID,
+		Labels:       input.Labels,
+		Estimate:     input.Estimate,
+		EstimateUnit: input.EstimateUnit,
+		Version:      expectedVersion,
+	}
+
+	if input.AssigneeUsernames != nil {
+		assignees, ok := app.getTeamMembersByUsernames(ctx, w, r, *input.AssigneeUsernames, team.ID)
+		if !ok {
+			return
+		}
+
+		update.Assignees = &assignees
+	}
+
+	previousMentions := task.Mentions
+
+	validator, err = app.services.TaskService.UpdateTask(ctx, update, task, updater.ID)
 	if err != nil {
 		switch {
 		case errors.Is(err, services.ErrNoPermission):
-			app.sendForbiddenResponse(w, r, "Only the assignee can start the task.")
-		case errors.Is(err, services.ErrTaskOverdue):
-			app.sendOverdueTaskResponse(w, r)
-		case errors.Is(err, services.ErrTaskStatusConflict):
-			msg := fmt.Sprintf("Only tasks with %s status can be started", models.TaskStatusOpen.String())
-			app.sendForbiddenResponse(w, r, msg)
+			app.sendForbiddenResponse(w, r, "You do not have permission to edit tasks in this team.")
 		case errors.Is(err, services.ErrEditConflict):
 			app.sendEditConflictResponse(w, r)
 		default:
@@ -230,51 +399,44 @@ func (app *application) handleTaskStart(w http.ResponseWriter, r *http.Request)
 
 		return
 	}
-
-	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
-		app.sendServerErrorResponse(w, r, err)
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
 	}
-}
 
-func (app *application) handleTaskCompletion(w http.ResponseWriter, r *http.Request) {
-	var input struct {
-		TaskID int64 `json:"task_id"`
-	}
+	app.sendMentionEmails(previousMentions, task.Mentions, updater, task, task.Description, false)
 
-	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
-		app.handleJSONRequestBodyParseError(w, r, err)
-		return
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskEnvelope(task), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
 	}
+}
 
-	updater := app.getRequestContextUser(r)
+func (app *application) handleTaskDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), remover.ID)
 	if !ok {
 		return
 	}
 
-	task, ok := app.getTaskByID(ctx, w, r, input.TaskID, team.ID)
+	taskID, err := app.parseInt64PathParam(r, "task_id")
+	if err != nil {
+		app.sendTaskNotFoundResponse(w, r)
+		return
+	}
+
+	task, ok := app.getTaskByID(ctx, w, r, taskID, team.ID)
 	if !ok {
 		return
 	}
 
-	err := app.services.TaskService.UpdateTaskStatus(ctx, task, models.TaskStatusCompleted, updater.ID)
-	if err != nil {
+	if err := app.services.TaskService.TrashTask(ctx, task, remover.ID); err != nil {
 		switch {
 		case errors.Is(err, services.ErrNoPermission):
-			msg := fmt.Sprintf("Only the task creator can mark the task as %s.", models.TaskStatusCompleted.String())
-			app.sendForbiddenResponse(w, r, msg)
-		case errors.Is(err, services.ErrTaskOverdue):
-			app.sendOverdueTaskResponse(w, r)
-		case errors.Is(err, services.ErrTaskStatusConflict):
-			msg := fmt.Sprintf(
-				"Only tasks with %s status can be marked as %s.",
-				models.TaskStatusInProgress.String(), models.TaskStatusCompleted.String(),
-			)
-			app.sendForbiddenResponse(w, r, msg)
+			app.sendForbiddenResponse(w, r, "Only the task creator or a team admin can delete this task.")
 		case errors.Is(err, services.ErrEditConflict):
 			app.sendEditConflictResponse(w, r)
 		default:
@@ -284,12 +446,17 @@ func (app *application) handleTaskCompletion(w http.ResponseWriter, r *http.Requ
 		return
 	}
 
-	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
+	msg := "The task has been moved to the trash."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
 		app.sendServerErrorResponse(w, r, err)
 	}
 }
 
-func (app *application) handleTaskCancellation(w http.ResponseWriter, r *http.Request) {
+func (app *application) handleRetrievalOfAllTrashedTasks(w http.ResponseWriter, r *http.Request) {
+	app.sendAllTasksResponse(w, r, true, false, false)
+}
+
+func (app *application) handleTaskRestoration(w http.ResponseWriter, r *http.Request) {
 	var input struct {
 		TaskID int64 `json:"task_id"`
 	}
@@ -299,34 +466,25 @@ func (app *application) handleTaskCancellation(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	updater := app.getRequestContextUser(r)
+	restorer := app.getRequestContextUser(r)
 
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 
-	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), restorer.ID)
 	if !ok {
 		return
 	}
 
-	task, ok := app.getTaskByID(ctx, w, r, input.TaskID, team.ID)
+	task, ok := app.getTrashedTaskByID(ctx, w, r, input.TaskID, team.ID)
 	if !ok {
 		return
 	}
 
-	err := app.services.TaskService.UpdateTaskStatus(ctx, task, models.TaskStatusCancelled, updater.ID)
-	if err != nil {
+	if err := app.services.TaskService.RestoreTask(ctx, task, restorer.ID); err != nil {
 		switch {
 		case errors.Is(err, services.ErrNoPermission):
-			msg := fmt.Sprintf("Only the task creator can mark the task as %s.", models.TaskStatusCancelled.String())
-			app.sendForbiddenResponse(w, r, msg)
-		case errors.Is(err, services.ErrTaskStatusConflict):
-			msg := fmt.Sprintf(
-				"Only tasks with %s an %s status can be marked as %s.",
-				models.TaskStatusOpen.String(), models.TaskStatusInProgress.String(),
-				models.TaskStatusCancelled.String(),
-			)
-			app.sendForbiddenResponse(w, r, msg)
+			app.sendForbiddenResponse(w, r, "Only the task creator or a team admin can restore this task.")
 		case errors.Is(err, services.ErrEditConflict):
 			app.sendEditConflictResponse(w, r)
 		default:
@@ -336,11 +494,335 @@ func (app *application) handleTaskCancellation(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if err := app.sendJSONResponse(w, http.StatusNoContent, envelope{}, nil); err != nil {
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTaskEnvelope(task), nil); err != nil {
 		app.sendServerErrorResponse(w, r, err)
 	}
 }
 
+func (app *application) handleTrashedTaskPurge(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), remover.ID)
+	if !ok {
+		return
+	}
+
+	taskID, err := app.parseInt64PathParam(r, "task_id")
+	if err != nil {
+		app.sendTrashedTaskNotFoundResponse(w, r)
+		return
+	}
+
+	task, ok := app.getTrashedTaskByID(ctx, w, r, taskID, team.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TaskService.PurgeTask(ctx, task, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendTrashedTaskNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "You do not have permission to permanently delete tasks in this team.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The task has been permanently deleted."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTaskHistoryRetrieval(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "created_at", []string{"created_at"}, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	events, metadata, err := app.services.TaskService.GetTaskHistory(ctx, task.ID, paginationOpts)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"history": events, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) sendAllTasksResponse(
+	w http.ResponseWriter,
+	r *http.Request,
+	isTrashed bool,
+	isChildrenListing bool,
+	isProjectListing bool,
+) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	var filters models.TaskFilters
+
+	defaultSort := "id"
+
+	if queryParams.Has("view") {
+		viewID := app.parseIntQueryParam(queryParams, "view", 0, validator)
+
+		if validator.HasErrors() {
+			app.sendValidationErrorResponse(w, r, validator.Errors)
+			return
+		}
+
+		view, ok := app.getTaskViewByID(ctx, w, r, int64(viewID), team.ID, retriever.ID)
+		if !ok {
+			return
+		}
+
+		filters = view.Filters
+
+		if view.Sort != "" {
+			defaultSort = view.Sort
+		}
+	}
+
+	filters.IsTrashed = isTrashed
+
+	status, priority := app.parseTaskFiltersFromQueryParams(queryParams, &filters, validator)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	paginationOpts := app.parseKeysetPaginationOptsFromQueryParams(
+		queryParams,
+		pagination.NewCursorScope(r.URL.Path, filters, status, priority),
+		defaultSort,
+		taskSortSafelist,
+		maxTaskSortKeys,
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if isChildrenListing {
+		taskID, err := app.parseInt64PathParam(r, "task_id")
+		if err != nil {
+			app.sendTaskNotFoundResponse(w, r)
+			return
+		}
+
+		parent, ok := app.getTaskByID(ctx, w, r, taskID, team.ID)
+		if !ok {
+			return
+		}
+
+		filters.ParentID = &parent.ID
+	}
+
+	if isProjectListing {
+		projectID, err := app.parseInt64PathParam(r, "project_id")
+		if err != nil {
+			app.sendProjectNotFoundResponse(w, r)
+			return
+		}
+
+		project, ok := app.getProjectByID(ctx, w, r, projectID, team.ID)
+		if !ok {
+			return
+		}
+
+		filters.ProjectID = &project.ID
+	}
+
+	tasks, metadata, validator, err := app.services.TaskService.GetAllTasks(
+		ctx,
+		filters,
+		status,
+		priority,
+		paginationOpts,
+		team.ID,
+	)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	envelope := envelope{"tasks": tasks, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) parseTaskFiltersFromQueryParams(
+	queryParams url.Values,
+	filters *models.TaskFilters,
+	validator *validator.Validator,
+) (status, priority []string) {
+	if queryParams.Has("q") {
+		filters.Query = strings.TrimSpace(app.parseStringQueryParam(queryParams, "q", ""))
+	}
+
+	if queryParams.Has("creator_username") {
+		filters.CreatorUsername = app.parseStringQueryParam(queryParams, "creator_username", "")
+	}
+
+	if queryParams.Has("assignee_username") {
+		filters.AssigneeUsername = app.parseStringQueryParam(queryParams, "assignee_username", "")
+	}
+
+	if queryParams.Has("watcher_username") {
+		filters.WatcherUsername = app.parseStringQueryParam(queryParams, "watcher_username", "")
+	}
+
+	status = app.parseCSVQueryParam(queryParams, "status", []string{})
+	if queryParams.Has("status") {
+		filters.Status = nil
+	}
+
+	priority = app.parseCSVQueryParam(queryParams, "priority", []string{})
+	if queryParams.Has("priority") {
+		filters.Priority = nil
+	}
+
+	if queryParams.Has("labels") {
+		filters.Labels = app.parseCSVQueryParam(queryParams, "labels", []string{})
+	}
+
+	defaultLabelsMatch := "any"
+	if filters.MatchAllLabels {
+		defaultLabelsMatch = "all"
+	}
+
+	labelsMatch := app.parseStringQueryParam(queryParams, "labels_match", defaultLabelsMatch)
+	validator.Check(labelsMatch == "any" || labelsMatch == "all", "labels_match", "Must be any or all.")
+	filters.MatchAllLabels = labelsMatch == "all"
+
+	if queryParams.Has("estimate_unit") {
+		estimateUnit, err := models.NewEstimateUnit(app.parseStringQueryParam(queryParams, "estimate_unit", ""))
+		if err != nil {
+			validator.AddError(
+				"estimate_unit",
+				fmt.Sprintf("Must be %s or %s.", models.EstimateUnitPoints, models.EstimateUnitHours),
+			)
+		}
+
+		filters.EstimateUnit = &estimateUnit
+	}
+
+	if queryParams.Has("min_estimate") {
+		minEstimate := app.parseFloat64QueryParam(queryParams, "min_estimate", 0, validator)
+		filters.MinEstimate = &minEstimate
+	}
+
+	if queryParams.Has("max_estimate") {
+		maxEstimate := app.parseFloat64QueryParam(queryParams, "max_estimate", 0, validator)
+		filters.MaxEstimate = &maxEstimate
+	}
+
+	if queryParams.Has("has_estimate") {
+		hasEstimate := app.parseBoolQueryParam(queryParams, "has_estimate", false, validator)
+		filters.HasEstimate = &hasEstimate
+	}
+
+	if queryParams.Has("milestone") {
+		milestoneID := app.parseIDOrNoneQueryParam(queryParams, "milestone", validator)
+		filters.MilestoneID = &milestoneID
+	}
+
+	if queryParams.Has("project") {
+		projectID := app.parseIDOrNoneQueryParam(queryParams, "project", validator)
+		filters.ProjectID = &projectID
+	}
+
+	if queryParams.Has("include_archived") {
+		filters.IncludeArchived = app.parseBoolQueryParam(queryParams, "include_archived", false, validator)
+	}
+
+	if queryParams.Has("created_before") {
+		createdBefore := app.parseTimeQueryParam(queryParams, "created_before", time.Time{}, validator)
+		filters.CreatedBefore = &createdBefore
+	}
+
+	if queryParams.Has("created_after") {
+		createdAfter := app.parseTimeQueryParam(queryParams, "created_after", time.Time{}, validator)
+		filters.CreatedAfter = &createdAfter
+	}
+
+	if queryParams.Has("due_before") {
+		dueBefore := app.parseTimeQueryParam(queryParams, "due_before", time.Time{}, validator)
+		filters.DueBefore = &dueBefore
+	}
+
+	if queryParams.Has("due_after") {
+		dueAfter := app.parseTimeQueryParam(queryParams, "due_after", time.Time{}, validator)
+		filters.DueAfter = &dueAfter
+	}
+
+	return status, priority
+}
+
+func (app *application) parseIDOrNoneQueryParam(
+	queryParams url.Values,
+	key string,
+	validator *validator.Validator,
+) int64 {
+	value := app.parseStringQueryParam(queryParams, key, "none")
+
+	if value == "none" {
+		return 0
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 1 {
+		validator.AddError(key, "Must be an ID or none.")
+		return 0
+	}
+
+	return id
+}
+
 func (app *application) newTaskEnvelope(task *models.Task) envelope {
 	return envelope{"task": task}
 }
@@ -360,10 +842,49 @@ func (app *application) getTaskByID(
 	return task, true
 }
 
+func (app *application) getTaskByPathParams(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	retrieverID int64,
+) (*models.Task, bool) {
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retrieverID)
+	if !ok {
+		return nil, false
+	}
+
+	taskID, err := app.parseInt64PathParam(r, "task_id")
+	if err != nil {
+		app.sendTaskNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	return app.getTaskByID(ctx, w, r, taskID, team.ID)
+}
+
+func (app *application) getTrashedTaskByID(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID, teamID int64,
+) (*models.Task, bool) {
+	task, err := app.services.TaskService.GetTrashedTaskByID(ctx, taskID, teamID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendTrashedTaskNotFoundResponse)
+		return nil, false
+	}
+
+	return task, true
+}
+
 func (app *application) sendTaskNotFoundResponse(w http.ResponseWriter, r *http.Request) {
 	app.sendNotFoundResponse(w, r, "A task with this ID does not exist or it does not belong to this team.")
 }
 
+func (app *application) sendTrashedTaskNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A task with this ID is not in the trash of this team.")
+}
+
 func (app *application) sendOverdueTaskResponse(w http.ResponseWriter, r *http.Request) {
 	app.sendForbiddenResponse(w, r, "This task is overdue.")
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
 	"github.com/svetoslaven/tasktracker/internal/services"
 	"github.com/svetoslaven/tasktracker/internal/validator"
 )
@@ -79,7 +80,14 @@ func (app *application) handleRetrievalOfAllTeams(w http.ResponseWriter, r *http
 		return
 	}
 
-	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "name", []string{"name"}, validator)
+	paginationOpts := app.parseKeysetPaginationOptsFromQueryParams(
+		queryParams,
+		pagination.NewCursorScope(r.URL.Path, filters),
+		"name",
+		[]string{"name"},
+		1,
+		validator,
+	)
 
 	if validator.HasErrors() {
 		app.sendValidationErrorResponse(w, r, validator.Errors)
@@ -104,8 +112,9 @@ func (app *application) handleRetrievalOfAllTeams(w http.ResponseWriter, r *http
 
 func (app *application) handleTeamPartialUpdate(w http.ResponseWriter, r *http.Request) {
 	var input struct {
-		Name     *string `json:"name"`
-		IsPublic *bool   `json:"is_public"`
+		Name          *string `json:"name"`
+		IsPublic      *bool   `json:"is_public"`
+		OverduePolicy *string `json:"overdue_policy"`
 	}
 
 	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
@@ -125,7 +134,7 @@ func (app *application) handleTeamPartialUpdate(w http.ResponseWriter, r *http.R
 		return
 	}
 
-	validator, err := app.services.TeamService.UpdateTeam(ctx, input.Name, input.IsPublic, team, updater.ID)
+	validator, err := app.services.TeamService.UpdateTeam(ctx, input.Name, input.IsPublic, input.OverduePolicy, team, updater.ID)
 	if err != nil {
 		switch {
 		case errors.Is(err, services.ErrEditConflict):
@@ -193,7 +202,14 @@ func (app *application) handleRetrievalOfAllTeamMembers(w http.ResponseWriter, r
 
 	roles := app.parseCSVQueryParam(queryParams, "roles", []string{})
 
-	paginationOpts := app.parsePaginationOptsFromQueryParams(queryParams, "", []string{""}, validator)
+	paginationOpts := app.parseKeysetPaginationOptsFromQueryParams(
+		queryParams,
+		pagination.NewCursorScope(r.URL.Path, filters, roles),
+		"",
+		[]string{""},
+		1,
+		validator,
+	)
 
 	if validator.HasErrors() {
 		app.sendValidationErrorResponse(w, r, validator.Errors)
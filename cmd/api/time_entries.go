@@ -0,0 +1,361 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleTimeEntryCreation(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		StartedAt   time.Time `json:"started_at"`
+		EndedAt     time.Time `json:"ended_at"`
+		Description string    `json:"description"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	user := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, user.ID)
+	if !ok {
+		return
+	}
+
+	entryInput := services.TimeEntryInput{
+		StartedAt:   input.StartedAt,
+		EndedAt:     input.EndedAt,
+		Description: input.Description,
+	}
+
+	entry, validator, err := app.services.TimeEntryService.CreateTimeEntry(ctx, entryInput, task, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can track time on tasks.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusCreated, app.newTimeEntryEnvelope(entry), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRetrievalOfAllTimeEntries(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	paginationOpts := app.parsePaginationOptsFromQueryParams(
+		queryParams,
+		"started_at",
+		[]string{"started_at", "created_at"},
+		validator,
+	)
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, retriever.ID)
+	if !ok {
+		return
+	}
+
+	entries, metadata, err := app.services.TimeEntryService.GetAllTimeEntries(ctx, paginationOpts, task.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	envelope := envelope{"time_entries": entries, "metadata": metadata}
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTimeEntryPartialUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		StartedAt   *time.Time `json:"started_at"`
+		EndedAt     *time.Time `json:"ended_at"`
+		Description *string    `json:"description"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, updater.ID)
+	if !ok {
+		return
+	}
+
+	entry, ok := app.getTimeEntryByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	update := services.TimeEntryUpdate{
+		StartedAt:   input.StartedAt,
+		EndedAt:     input.EndedAt,
+		Description: input.Description,
+	}
+
+	validator, err := app.services.TimeEntryService.UpdateTimeEntry(ctx, update, entry, task, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the owner of the time entry or a team admin can edit it.")
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTimeEntryEnvelope(entry), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTimeEntryDeletion(w http.ResponseWriter, r *http.Request) {
+	remover := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	task, ok := app.getTaskByPathParams(ctx, w, r, remover.ID)
+	if !ok {
+		return
+	}
+
+	entry, ok := app.getTimeEntryByPathParam(ctx, w, r, task.ID)
+	if !ok {
+		return
+	}
+
+	if err := app.services.TimeEntryService.DeleteTimeEntry(ctx, entry, task, remover.ID); err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendTimeEntryNotFoundResponse(w, r)
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the owner of the time entry or a team admin can delete it.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	msg := "The time entry has been deleted successfully."
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newMessageEnvelope(msg), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleRunningTimerRetrieval(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	entry, err := app.services.TimeEntryService.GetRunningTimeEntry(ctx, retriever.ID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendNoRunningTimerResponse)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTimeEntryEnvelope(entry), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTimerStart(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		TeamName    string `json:"team_name"`
+		TaskID      int64  `json:"task_id"`
+		Description string `json:"description"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	user := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, input.TeamName, user.ID)
+	if !ok {
+		return
+	}
+
+	task, ok := app.getTaskByID(ctx, w, r, input.TaskID, team.ID)
+	if !ok {
+		return
+	}
+
+	entry, validator, err := app.services.TimeEntryService.StartTimer(ctx, input.Description, task, user)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can track time on tasks.")
+		case errors.Is(err, services.ErrTimerAlreadyRunning):
+			app.sendErrorResponse(w, r, http.StatusConflict, "You already have a running timer. Stop it before starting a new one.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTimeEntryEnvelope(entry), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTimerStop(w http.ResponseWriter, r *http.Request) {
+	user := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	entry, err := app.services.TimeEntryService.StopTimer(ctx, user.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoRecordsFound):
+			app.sendNoRunningTimerResponse(w, r)
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newTimeEntryEnvelope(entry), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleTimeReportRetrieval(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	to := app.parseTimeQueryParam(queryParams, "to", time.Now(), validator)
+	from := app.parseTimeQueryParam(queryParams, "from", to.AddDate(0, 0, -7), validator)
+	groupBy := app.parseStringQueryParam(queryParams, "group_by", "member")
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	report, validator, err := app.services.TimeEntryService.GetTimeReport(ctx, from, to, groupBy, team.ID, retriever.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can view time reports.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"report": report}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newTimeEntryEnvelope(entry *models.TimeEntry) envelope {
+	return envelope{"time_entry": entry}
+}
+
+func (app *application) getTimeEntryByPathParam(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	taskID int64,
+) (*models.TimeEntry, bool) {
+	entryID, err := app.parseInt64PathParam(r, "time_entry_id")
+	if err != nil {
+		app.sendTimeEntryNotFoundResponse(w, r)
+		return nil, false
+	}
+
+	entry, err := app.services.TimeEntryService.GetTimeEntryByID(ctx, entryID, taskID)
+	if err != nil {
+		app.handleServiceRetrievalError(w, r, err, app.sendTimeEntryNotFoundResponse)
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (app *application) sendTimeEntryNotFoundResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "A time entry with this ID does not exist or it does not belong to this task.")
+}
+
+func (app *application) sendNoRunningTimerResponse(w http.ResponseWriter, r *http.Request) {
+	app.sendNotFoundResponse(w, r, "You do not have a running timer.")
+}
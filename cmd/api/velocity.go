@@ -0,0 +1,59 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func (app *application) handleVelocityRetrieval(w http.ResponseWriter, r *http.Request) {
+	queryParams := r.URL.Query()
+
+	validator := validator.New()
+
+	opts := services.VelocityOptions{
+		Period:          app.parseStringQueryParam(queryParams, "period", "week"),
+		Count:           app.parseIntQueryParam(queryParams, "count", 6, validator),
+		IterationStart:  app.parseTimeQueryParam(queryParams, "iteration_start", time.Time{}, validator),
+		IterationLength: app.parseIntQueryParam(queryParams, "iteration_length", 14, validator),
+	}
+
+	if validator.HasErrors() {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	velocity, validator, err := app.services.TaskService.GetVelocity(ctx, opts, team.ID, retriever.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only team members can view the team velocity.")
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, envelope{"velocity": velocity}, nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+)
+
+func (app *application) handleWorkflowRetrieval(w http.ResponseWriter, r *http.Request) {
+	retriever := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), retriever.ID)
+	if !ok {
+		return
+	}
+
+	workflow, err := app.services.WorkflowService.GetWorkflow(ctx, team.ID)
+	if err != nil {
+		app.sendServerErrorResponse(w, r, err)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newWorkflowEnvelope(workflow), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) handleWorkflowUpdate(w http.ResponseWriter, r *http.Request) {
+	var input struct {
+		Statuses []struct {
+			Name      string `json:"name"`
+			Category  string `json:"category"`
+			IsInitial bool   `json:"is_initial"`
+		} `json:"statuses"`
+		Transitions []struct {
+			From   string   `json:"from"`
+			To     string   `json:"to"`
+			Actors []string `json:"actors"`
+		} `json:"transitions"`
+		StatusMapping map[string]string `json:"status_mapping"`
+	}
+
+	if err := app.parseJSONRequestBody(w, r, &input); err != nil {
+		app.handleJSONRequestBodyParseError(w, r, err)
+		return
+	}
+
+	workflowInput := services.WorkflowInput{StatusMapping: input.StatusMapping}
+
+	for _, status := range input.Statuses {
+		workflowInput.Statuses = append(workflowInput.Statuses, services.WorkflowStatusInput{
+			Name:      status.Name,
+			Category:  status.Category,
+			IsInitial: status.IsInitial,
+		})
+	}
+
+	for _, transition := range input.Transitions {
+		workflowInput.Transitions = append(workflowInput.Transitions, services.WorkflowTransitionInput{
+			From:   transition.From,
+			To:     transition.To,
+			Actors: transition.Actors,
+		})
+	}
+
+	updater := app.getRequestContextUser(r)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+
+	team, ok := app.getTeamByName(ctx, w, r, r.PathValue("team_name"), updater.ID)
+	if !ok {
+		return
+	}
+
+	workflow, validator, err := app.services.WorkflowService.UpdateWorkflow(ctx, workflowInput, team.ID, updater.ID)
+	if err != nil {
+		switch {
+		case errors.Is(err, services.ErrNoPermission):
+			app.sendForbiddenResponse(w, r, "Only the team owner can change the workflow.")
+		case errors.Is(err, services.ErrEditConflict):
+			app.sendEditConflictResponse(w, r)
+		default:
+			app.sendServerErrorResponse(w, r, err)
+		}
+
+		return
+	}
+	if validator != nil {
+		app.sendValidationErrorResponse(w, r, validator.Errors)
+		return
+	}
+
+	if err := app.sendJSONResponse(w, http.StatusOK, app.newWorkflowEnvelope(workflow), nil); err != nil {
+		app.sendServerErrorResponse(w, r, err)
+	}
+}
+
+func (app *application) newWorkflowEnvelope(workflow *models.Workflow) envelope {
+	return envelope{"workflow": workflow}
+}
@@ -0,0 +1,22 @@
+package mentions
+
+import (
+	"regexp"
+	"slices"
+)
+
+var mentionRX = regexp.MustCompile(`(?:^|[^A-Za-z0-9@-])@([A-Za-z0-9]+(?:-[A-Za-z0-9]+)*)`)
+
+func Parse(texts ...string) []string {
+	var usernames []string
+
+	for _, text := range texts {
+		for _, match := range mentionRX.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(usernames, match[1]) {
+				usernames = append(usernames, match[1])
+			}
+		}
+	}
+
+	return usernames
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type EstimateUnit int
+
+const (
+	EstimateUnitPoints EstimateUnit = 1
+	EstimateUnitHours  EstimateUnit = 2
+)
+
+func NewEstimateUnit(unit string) (EstimateUnit, error) {
+	switch strings.ToLower(unit) {
+	case EstimateUnitPoints.String():
+		return EstimateUnitPoints, nil
+	case EstimateUnitHours.String():
+		return EstimateUnitHours, nil
+	default:
+		return EstimateUnitPoints, errors.New("models: invalid estimate unit")
+	}
+}
+
+func (u EstimateUnit) String() string {
+	switch u {
+	case EstimateUnitPoints:
+		return "points"
+	case EstimateUnitHours:
+		return "hours"
+	default:
+		panic("invalid estimate unit")
+	}
+}
+
+func (u EstimateUnit) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(u.String())), nil
+}
+
+func (u *EstimateUnit) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewEstimateUnit(value)
+	if err != nil {
+		return err
+	}
+
+	*u = parsed
+	return nil
+}
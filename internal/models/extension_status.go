@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type ExtensionStatus int
+
+const (
+	ExtensionStatusPending  ExtensionStatus = 1
+	ExtensionStatusApproved ExtensionStatus = 2
+	ExtensionStatusRejected ExtensionStatus = 3
+)
+
+func NewExtensionStatus(status string) (ExtensionStatus, error) {
+	switch strings.ToLower(status) {
+	case ExtensionStatusPending.String():
+		return ExtensionStatusPending, nil
+	case ExtensionStatusApproved.String():
+		return ExtensionStatusApproved, nil
+	case ExtensionStatusRejected.String():
+		return ExtensionStatusRejected, nil
+	default:
+		return ExtensionStatusPending, errors.New("models: invalid extension status")
+	}
+}
+
+func (s ExtensionStatus) String() string {
+	switch s {
+	case ExtensionStatusPending:
+		return "pending"
+	case ExtensionStatusApproved:
+		return "approved"
+	case ExtensionStatusRejected:
+		return "rejected"
+	default:
+		panic("invalid extension status")
+	}
+}
+
+func (s ExtensionStatus) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.String())), nil
+}
+
+func (s *ExtensionStatus) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewExtensionStatus(value)
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+	return nil
+}
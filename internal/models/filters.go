@@ -18,12 +18,45 @@ type MembershipFilters struct {
 }
 
 type TaskFilters struct {
-	CreatedBefore    *time.Time
-	CreatedAfter     *time.Time
-	DueBefore        *time.Time
-	DueAfter         *time.Time
-	Status           []TaskStatus
-	Priority         []TaskPriority
-	CreatorUsername  string
-	AssigneeUsername string
+	Query            string         `json:"q,omitempty"`
+	CreatedBefore    *time.Time     `json:"created_before,omitempty"`
+	CreatedAfter     *time.Time     `json:"created_after,omitempty"`
+	DueBefore        *time.Time     `json:"due_before,omitempty"`
+	DueAfter         *time.Time     `json:"due_after,omitempty"`
+	Status           []string       `json:"status,omitempty"`
+	Priority         []TaskPriority `json:"priority,omitempty"`
+	CreatorUsername  string         `json:"creator_username,omitempty"`
+	AssigneeUsername string         `json:"assignee_username,omitempty"`
+	WatcherUsername  string         `json:"watcher_username,omitempty"`
+	Labels           []string       `json:"labels,omitempty"`
+	MatchAllLabels   bool           `json:"match_all_labels,omitempty"`
+	EstimateUnit     *EstimateUnit  `json:"estimate_unit,omitempty"`
+	MinEstimate      *float64       `json:"min_estimate,omitempty"`
+	MaxEstimate      *float64       `json:"max_estimate,omitempty"`
+	HasEstimate      *bool          `json:"has_estimate,omitempty"`
+	MilestoneID      *int64         `json:"milestone,omitempty"`
+	ProjectID        *int64         `json:"project,omitempty"`
+	IncludeArchived  bool           `json:"include_archived,omitempty"`
+	ParentID         *int64         `json:"-"`
+	IsTrashed        bool           `json:"-"`
+}
+
+type NotificationFilters struct {
+	IsRead *bool
+}
+
+type MilestoneFilters struct {
+	States []MilestoneState
+}
+
+type ProjectFilters struct {
+	IsArchived bool
+}
+
+type CommentFilters struct {
+	ParentID *int64
+}
+
+type DueDateExtensionFilters struct {
+	Status *ExtensionStatus
 }
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type MilestoneState int
+
+const (
+	MilestoneStateOpen   MilestoneState = 1
+	MilestoneStateClosed MilestoneState = 2
+)
+
+func NewMilestoneState(state string) (MilestoneState, error) {
+	switch strings.ToLower(state) {
+	case MilestoneStateOpen.String():
+		return MilestoneStateOpen, nil
+	case MilestoneStateClosed.String():
+		return MilestoneStateClosed, nil
+	default:
+		return MilestoneStateOpen, errors.New("models: invalid milestone state")
+	}
+}
+
+func (s MilestoneState) String() string {
+	switch s {
+	case MilestoneStateOpen:
+		return "open"
+	case MilestoneStateClosed:
+		return "closed"
+	default:
+		panic("invalid milestone state")
+	}
+}
+
+func (s MilestoneState) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(s.String())), nil
+}
+
+func (s *MilestoneState) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewMilestoneState(value)
+	if err != nil {
+		return err
+	}
+
+	*s = parsed
+	return nil
+}
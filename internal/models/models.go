@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+)
 
 type User struct {
 	ID           int64  `json:"-"`
@@ -20,10 +24,31 @@ type Token struct {
 }
 
 type Team struct {
-	ID       int64  `json:"-"`
-	Name     string `json:"name"`
-	IsPublic bool   `json:"is_public"`
-	Version  int    `json:"-"`
+	ID            int64         `json:"-"`
+	Name          string        `json:"name"`
+	IsPublic      bool          `json:"is_public"`
+	OverduePolicy OverduePolicy `json:"overdue_policy"`
+	Version       int           `json:"-"`
+}
+
+type Workflow struct {
+	Statuses    []*WorkflowStatus     `json:"statuses"`
+	Transitions []*WorkflowTransition `json:"transitions"`
+	TeamID      int64                 `json:"-"`
+}
+
+type WorkflowStatus struct {
+	ID        int64          `json:"-"`
+	Name      string         `json:"name"`
+	Category  StatusCategory `json:"category"`
+	IsInitial bool           `json:"is_initial"`
+	TaskCount int            `json:"task_count"`
+}
+
+type WorkflowTransition struct {
+	From   string            `json:"from"`
+	To     string            `json:"to"`
+	Actors []TransitionActor `json:"actors"`
 }
 
 type Invitation struct {
@@ -41,14 +66,267 @@ type Membership struct {
 }
 
 type Task struct {
-	ID          int64        `json:"id"`
-	CreatedAt   time.Time    `json:"created_at"`
-	Due         time.Time    `json:"due"`
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	Status      TaskStatus   `json:"status"`
-	Priority    TaskPriority `json:"priority"`
-	Creator     *User        `json:"creator"`
-	Assignee    *User        `json:"assignee"`
-	Version     int          `json:"-"`
+	ID          int64           `json:"id"`
+	CreatedAt   time.Time       `json:"created_at"`
+	Due         time.Time       `json:"due"`
+	Title       string          `json:"title"`
+	Description string          `json:"description"`
+	Status      TaskStatus      `json:"status"`
+	Rank        string          `json:"rank"`
+	Priority    TaskPriority    `json:"priority"`
+	Creator     *User           `json:"creator"`
+	Assignees   []*User         `json:"assignees"`
+	Watchers    []*User         `json:"watchers"`
+	Mentions    []*User         `json:"mentions"`
+	ParentID    *int64          `json:"parent_id"`
+	MilestoneID *int64          `json:"milestone_id"`
+	ProjectID   *int64          `json:"project_id"`
+	Labels      []*Label        `json:"labels"`
+	Estimate    *TaskEstimate   `json:"estimate"`
+	IsLate      bool            `json:"is_late"`
+	Progress    *TaskProgress   `json:"progress,omitempty"`
+	Highlights  *TaskHighlights `json:"highlights,omitempty"`
+	BlockedBy   []*TaskRef      `json:"blocked_by,omitempty"`
+	Blocks      []*TaskRef      `json:"blocks,omitempty"`
+	DeletedAt   *time.Time      `json:"deleted_at,omitempty"`
+	TeamID      int64           `json:"-"`
+	Version     int             `json:"version"`
+}
+
+type TaskEstimate struct {
+	Value float64      `json:"value"`
+	Unit  EstimateUnit `json:"unit"`
+}
+
+type DueDateExtension struct {
+	ID           int64           `json:"id"`
+	CreatedAt    time.Time       `json:"created_at"`
+	PreviousDue  time.Time       `json:"previous_due"`
+	ProposedDue  time.Time       `json:"proposed_due"`
+	Reason       string          `json:"reason"`
+	Status       ExtensionStatus `json:"status"`
+	Requester    *User           `json:"requester"`
+	Decider      *User           `json:"decider"`
+	DecidedAt    *time.Time      `json:"decided_at"`
+	DecisionNote string          `json:"decision_note"`
+	TaskID       int64           `json:"task_id"`
+	Version      int             `json:"-"`
+}
+
+type TaskCompletion struct {
+	CompletedAt time.Time
+	Estimate    *TaskEstimate
+}
+
+type Velocity struct {
+	Period        string            `json:"period"`
+	Iterations    []*VelocityPeriod `json:"iterations"`
+	AveragePoints float64           `json:"average_points"`
+	AverageHours  float64           `json:"average_hours"`
+}
+
+type VelocityPeriod struct {
+	Start           time.Time `json:"start"`
+	End             time.Time `json:"end"`
+	CompletedTasks  int       `json:"completed_tasks"`
+	CompletedPoints float64   `json:"completed_points"`
+	CompletedHours  float64   `json:"completed_hours"`
+}
+
+type RecurringTask struct {
+	ID               int64        `json:"id"`
+	CreatedAt        time.Time    `json:"created_at"`
+	Title            string       `json:"title"`
+	Description      string       `json:"description"`
+	Priority         TaskPriority `json:"priority"`
+	Labels           []string     `json:"labels"`
+	RRule            string       `json:"rrule"`
+	StartsAt         time.Time    `json:"starts_at"`
+	IsPaused         bool         `json:"is_paused"`
+	NextOccurrenceAt *time.Time   `json:"next_occurrence_at"`
+	Creator          *User        `json:"creator"`
+	Assignees        []*User      `json:"assignees"`
+	GeneratedCount   int          `json:"-"`
+	TeamID           int64        `json:"-"`
+	Version          int          `json:"-"`
+}
+
+type Occurrence struct {
+	At       time.Time `json:"at"`
+	Assignee *User     `json:"assignee"`
+}
+
+type TaskTemplate struct {
+	ID           int64        `json:"id"`
+	CreatedAt    time.Time    `json:"created_at"`
+	Name         string       `json:"name"`
+	Title        string       `json:"title"`
+	Description  string       `json:"description"`
+	Priority     TaskPriority `json:"priority"`
+	DueOffset    string       `json:"due_offset"`
+	Labels       []string     `json:"labels"`
+	Placeholders []string     `json:"placeholders"`
+	TeamID       int64        `json:"-"`
+	Version      int          `json:"-"`
+}
+
+type TaskView struct {
+	ID        int64       `json:"id"`
+	CreatedAt time.Time   `json:"created_at"`
+	Name      string      `json:"name"`
+	Owner     *User       `json:"owner"`
+	IsShared  bool        `json:"is_shared"`
+	Filters   TaskFilters `json:"filters"`
+	Sort      string      `json:"sort"`
+	TeamID    int64       `json:"-"`
+	Version   int         `json:"-"`
+}
+
+type Milestone struct {
+	ID        int64              `json:"id"`
+	CreatedAt time.Time          `json:"created_at"`
+	Name      string             `json:"name"`
+	StartsAt  time.Time          `json:"starts_at"`
+	EndsAt    time.Time          `json:"ends_at"`
+	State     MilestoneState     `json:"state"`
+	ClosedAt  *time.Time         `json:"closed_at,omitempty"`
+	Progress  *MilestoneProgress `json:"progress"`
+	TeamID    int64              `json:"-"`
+	Version   int                `json:"-"`
+}
+
+type MilestoneProgress struct {
+	CompletedTasks int                 `json:"completed_tasks"`
+	TotalTasks     int                 `json:"total_tasks"`
+	Estimates      []*EstimateProgress `json:"estimates"`
+}
+
+type EstimateProgress struct {
+	Unit      EstimateUnit `json:"unit"`
+	Completed float64      `json:"completed"`
+	Total     float64      `json:"total"`
+}
+
+type Project struct {
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Lead        *User     `json:"lead"`
+	IsArchived  bool      `json:"is_archived"`
+	TeamID      int64     `json:"-"`
+	Version     int       `json:"-"`
+}
+
+type Label struct {
+	ID        int64     `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	Name      string    `json:"name"`
+	Color     string    `json:"color"`
+	TeamID    int64     `json:"-"`
+	Version   int       `json:"-"`
+}
+
+type BoardColumn struct {
+	Status   string              `json:"status"`
+	Category StatusCategory      `json:"category"`
+	Count    int                 `json:"count"`
+	Tasks    []*Task             `json:"tasks"`
+	Metadata pagination.Metadata `json:"metadata"`
+}
+
+type TaskRef struct {
+	ID     int64      `json:"id"`
+	Title  string     `json:"title"`
+	Status TaskStatus `json:"status"`
+}
+
+type Comment struct {
+	ID         int64     `json:"id"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	Body       string    `json:"body"`
+	Author     *User     `json:"author"`
+	ParentID   *int64    `json:"parent_id"`
+	ReplyCount int       `json:"reply_count"`
+	Mentions   []*User   `json:"mentions"`
+	TaskID     int64     `json:"-"`
+	Version    int       `json:"-"`
+}
+
+type Attachment struct {
+	ID          int64     `json:"id"`
+	CreatedAt   time.Time `json:"created_at"`
+	Filename    string    `json:"filename"`
+	ContentType string    `json:"content_type"`
+	Size        int64     `json:"size"`
+	Uploader    *User     `json:"uploader"`
+	StorageKey  string    `json:"-"`
+	TaskID      int64     `json:"-"`
+}
+
+type TimeEntry struct {
+	ID              int64      `json:"id"`
+	CreatedAt       time.Time  `json:"created_at"`
+	StartedAt       time.Time  `json:"started_at"`
+	EndedAt         *time.Time `json:"ended_at"`
+	DurationSeconds int64      `json:"duration_seconds"`
+	Description     string     `json:"description"`
+	User            *User      `json:"user"`
+	TaskID          int64      `json:"task_id"`
+	Version         int        `json:"-"`
+}
+
+type TimeReport struct {
+	From         time.Time        `json:"from"`
+	To           time.Time        `json:"to"`
+	GroupBy      string           `json:"group_by"`
+	TotalSeconds int64            `json:"total_seconds"`
+	Rows         []*TimeReportRow `json:"rows"`
+}
+
+type TimeReportRow struct {
+	MemberUsername string `json:"member_username,omitempty"`
+	TaskID         int64  `json:"task_id,omitempty"`
+	TaskTitle      string `json:"task_title,omitempty"`
+	Day            string `json:"day,omitempty"`
+	TotalSeconds   int64  `json:"total_seconds"`
+	EntryCount     int    `json:"entry_count"`
+}
+
+type TaskHighlights struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+type TaskProgress struct {
+	Completed int `json:"completed"`
+	Total     int `json:"total"`
+}
+
+type TaskChange struct {
+	Field    string
+	OldValue string
+	NewValue string
+}
+
+type Notification struct {
+	ID        int64            `json:"id"`
+	CreatedAt time.Time        `json:"created_at"`
+	Kind      NotificationKind `json:"kind"`
+	Actor     *User            `json:"actor"`
+	TeamName  string           `json:"team_name"`
+	Task      *TaskRef         `json:"task"`
+	CommentID *int64           `json:"comment_id"`
+	ReadAt    *time.Time       `json:"read_at"`
+}
+
+type TaskEvent struct {
+	ID        int64           `json:"id"`
+	CreatedAt time.Time       `json:"created_at"`
+	Actor     *User           `json:"actor"`
+	Action    TaskEventAction `json:"action"`
+	Field     string          `json:"field,omitempty"`
+	OldValue  string          `json:"old_value,omitempty"`
+	NewValue  string          `json:"new_value,omitempty"`
 }
@@ -0,0 +1,22 @@
+package models
+
+import "strconv"
+
+type NotificationKind int
+
+const (
+	NotificationKindMention NotificationKind = 1
+)
+
+func (k NotificationKind) String() string {
+	switch k {
+	case NotificationKindMention:
+		return "mention"
+	default:
+		panic("invalid notification kind")
+	}
+}
+
+func (k NotificationKind) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(k.String())), nil
+}
@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type OverduePolicy int
+
+const (
+	OverduePolicyBlock OverduePolicy = 1
+	OverduePolicyAllow OverduePolicy = 2
+	OverduePolicyFlag  OverduePolicy = 3
+)
+
+func NewOverduePolicy(policy string) (OverduePolicy, error) {
+	switch strings.ToLower(policy) {
+	case OverduePolicyBlock.String():
+		return OverduePolicyBlock, nil
+	case OverduePolicyAllow.String():
+		return OverduePolicyAllow, nil
+	case OverduePolicyFlag.String():
+		return OverduePolicyFlag, nil
+	default:
+		return OverduePolicyBlock, errors.New("models: invalid overdue policy")
+	}
+}
+
+func (p OverduePolicy) String() string {
+	switch p {
+	case OverduePolicyBlock:
+		return "block"
+	case OverduePolicyAllow:
+		return "allow"
+	case OverduePolicyFlag:
+		return "flag"
+	default:
+		panic("invalid overdue policy")
+	}
+}
+
+func (p OverduePolicy) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(p.String())), nil
+}
+
+func (p *OverduePolicy) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewOverduePolicy(value)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
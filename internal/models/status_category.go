@@ -0,0 +1,65 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type StatusCategory int
+
+const (
+	StatusCategoryTodo      StatusCategory = 1
+	StatusCategoryDoing     StatusCategory = 2
+	StatusCategoryDone      StatusCategory = 3
+	StatusCategoryCancelled StatusCategory = 4
+)
+
+func NewStatusCategory(category string) (StatusCategory, error) {
+	switch strings.ToLower(category) {
+	case StatusCategoryTodo.String():
+		return StatusCategoryTodo, nil
+	case StatusCategoryDoing.String():
+		return StatusCategoryDoing, nil
+	case StatusCategoryDone.String():
+		return StatusCategoryDone, nil
+	case StatusCategoryCancelled.String():
+		return StatusCategoryCancelled, nil
+	default:
+		return StatusCategoryTodo, errors.New("models: invalid status category")
+	}
+}
+
+func (c StatusCategory) String() string {
+	switch c {
+	case StatusCategoryTodo:
+		return "todo"
+	case StatusCategoryDoing:
+		return "doing"
+	case StatusCategoryDone:
+		return "done"
+	case StatusCategoryCancelled:
+		return "cancelled"
+	default:
+		panic("invalid status category")
+	}
+}
+
+func (c StatusCategory) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(c.String())), nil
+}
+
+func (c *StatusCategory) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewStatusCategory(value)
+	if err != nil {
+		return err
+	}
+
+	*c = parsed
+	return nil
+}
@@ -0,0 +1,43 @@
+package models
+
+import "strconv"
+
+type TaskEventAction int
+
+const (
+	TaskEventActionCreated            TaskEventAction = 1
+	TaskEventActionUpdated            TaskEventAction = 2
+	TaskEventActionStatusChanged      TaskEventAction = 3
+	TaskEventActionTrashed            TaskEventAction = 4
+	TaskEventActionRestored           TaskEventAction = 5
+	TaskEventActionExtensionRequested TaskEventAction = 6
+	TaskEventActionExtensionApproved  TaskEventAction = 7
+	TaskEventActionExtensionRejected  TaskEventAction = 8
+)
+
+func (a TaskEventAction) String() string {
+	switch a {
+	case TaskEventActionCreated:
+		return "created"
+	case TaskEventActionUpdated:
+		return "updated"
+	case TaskEventActionStatusChanged:
+		return "status-changed"
+	case TaskEventActionTrashed:
+		return "trashed"
+	case TaskEventActionRestored:
+		return "restored"
+	case TaskEventActionExtensionRequested:
+		return "extension-requested"
+	case TaskEventActionExtensionApproved:
+		return "extension-approved"
+	case TaskEventActionExtensionRejected:
+		return "extension-rejected"
+	default:
+		panic("invalid task event action")
+	}
+}
+
+func (a TaskEventAction) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
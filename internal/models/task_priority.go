@@ -43,3 +43,18 @@ func (p TaskPriority) String() string {
 func (p TaskPriority) MarshalJSON() ([]byte, error) {
 	return []byte(strconv.Quote(p.String())), nil
 }
+
+func (p *TaskPriority) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewTaskPriority(value)
+	if err != nil {
+		return err
+	}
+
+	*p = parsed
+	return nil
+}
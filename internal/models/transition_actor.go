@@ -0,0 +1,60 @@
+package models
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+type TransitionActor int
+
+const (
+	TransitionActorCreator  TransitionActor = 1
+	TransitionActorAssignee TransitionActor = 2
+	TransitionActorLeader   TransitionActor = 3
+)
+
+func NewTransitionActor(actor string) (TransitionActor, error) {
+	switch strings.ToLower(actor) {
+	case TransitionActorCreator.String():
+		return TransitionActorCreator, nil
+	case TransitionActorAssignee.String():
+		return TransitionActorAssignee, nil
+	case TransitionActorLeader.String():
+		return TransitionActorLeader, nil
+	default:
+		return TransitionActorCreator, errors.New("models: invalid transition actor")
+	}
+}
+
+func (a TransitionActor) String() string {
+	switch a {
+	case TransitionActorCreator:
+		return "creator"
+	case TransitionActorAssignee:
+		return "assignee"
+	case TransitionActorLeader:
+		return "leader"
+	default:
+		panic("invalid transition actor")
+	}
+}
+
+func (a TransitionActor) MarshalJSON() ([]byte, error) {
+	return []byte(strconv.Quote(a.String())), nil
+}
+
+func (a *TransitionActor) UnmarshalJSON(data []byte) error {
+	value, err := strconv.Unquote(string(data))
+	if err != nil {
+		return err
+	}
+
+	parsed, err := NewTransitionActor(value)
+	if err != nil {
+		return err
+	}
+
+	*a = parsed
+	return nil
+}
@@ -0,0 +1,97 @@
+package pagination
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+)
+
+const (
+	CursorKey       = "cursor"
+	IncludeTotalKey = "include_total"
+)
+
+const (
+	cursorSignatureSeparator = "."
+	cursorScopeLength        = 16
+)
+
+var ErrInvalidCursor = errors.New("pagination: invalid cursor")
+
+type Cursor struct {
+	Sort       string   `json:"s"`
+	Values     []string `json:"v"`
+	IsBackward bool     `json:"b,omitempty"`
+	Scope      string   `json:"f"`
+}
+
+type CursorSigner struct {
+	secret []byte
+}
+
+func NewCursorSigner(secret []byte) *CursorSigner {
+	return &CursorSigner{secret: secret}
+}
+
+func NewCursorScope(endpoint string, filters ...any) string {
+	encodedFilters, err := json.Marshal(filters)
+	if err != nil {
+		panic("failed to marshal cursor filters: " + err.Error())
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(endpoint))
+	hash.Write([]byte{0})
+	hash.Write(encodedFilters)
+
+	return base64.RawURLEncoding.EncodeToString(hash.Sum(nil)[:cursorScopeLength])
+}
+
+func (s *CursorSigner) Encode(cursor Cursor) string {
+	payload, err := json.Marshal(cursor)
+	if err != nil {
+		panic("failed to marshal cursor: " + err.Error())
+	}
+
+	encodedPayload := base64.RawURLEncoding.EncodeToString(payload)
+
+	return encodedPayload + cursorSignatureSeparator + base64.RawURLEncoding.EncodeToString(s.sign(encodedPayload))
+}
+
+func (s *CursorSigner) Decode(token string) (Cursor, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, cursorSignatureSeparator)
+	if !found {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	if !hmac.Equal(signature, s.sign(encodedPayload)) {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	var cursor Cursor
+
+	if err := json.Unmarshal(payload, &cursor); err != nil {
+		return Cursor{}, ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+func (s *CursorSigner) sign(encodedPayload string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedPayload))
+	return mac.Sum(nil)
+}
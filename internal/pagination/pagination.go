@@ -1,6 +1,7 @@
 package pagination
 
 import (
+	"fmt"
 	"strings"
 
 	"github.com/svetoslaven/tasktracker/internal/validator"
@@ -12,7 +13,11 @@ const (
 	SortKey     = "sort"
 )
 
-const sortDescendingSuffix = "_desc"
+const (
+	sortDescendingSuffix = "_desc"
+	sortDescendingPrefix = "-"
+	sortKeySeparator     = ","
+)
 
 const (
 	minPage = 1
@@ -27,17 +32,49 @@ type Options struct {
 	pageSize     int
 	sort         string
 	sortSafelist []string
+	maxSortKeys  int
+
+	isCursorMode bool
+	cursor       string
+	cursorSigner *CursorSigner
+	cursorScope  string
+	includeTotal bool
+}
+
+type SortField struct {
+	Column       string
+	IsDescending bool
 }
 
 func NewOptions(page, pageSize int, sort string, sortSafelist []string) Options {
+	return NewMultiSortOptions(page, pageSize, sort, sortSafelist, 1)
+}
+
+func NewMultiSortOptions(page, pageSize int, sort string, sortSafelist []string, maxSortKeys int) Options {
 	return Options{
 		page:         page,
 		pageSize:     pageSize,
 		sort:         strings.ToLower(sort),
 		sortSafelist: sortSafelist,
+		maxSortKeys:  maxSortKeys,
+		includeTotal: true,
 	}
 }
 
+func (opts Options) WithCursor(cursor string, cursorSigner *CursorSigner, cursorScope string) Options {
+	opts.isCursorMode = true
+	opts.cursor = cursor
+	opts.cursorSigner = cursorSigner
+	opts.cursorScope = cursorScope
+	opts.includeTotal = false
+	return opts
+}
+
+func (opts Options) WithIncludeTotal(includeTotal bool) Options {
+	opts.includeTotal = includeTotal
+	return opts
+}
+
 func (opts Options) Validate(validator *validator.Validator) {
 	validator.CheckGreaterThanOrEqualTo(opts.page, minPage, PageKey)
 	validator.CheckLessThanOrEqualTo(opts.page, maxPage, PageKey)
@@ -46,6 +83,24 @@ func (opts Options) Validate(validator *validator.Validator) {
 	validator.CheckLessThanOrEqualTo(opts.pageSize, maxPageSize, PageSizeKey)
 
 	validator.Check(opts.isSortSafe(), SortKey, "Unsupported sort.")
+	validator.Check(
+		len(opts.sortKeys()) <= opts.maxSortKeys,
+		SortKey,
+		fmt.Sprintf("Must contain no more than %d sort keys.", opts.maxSortKeys),
+	)
+
+	if opts.isCursorMode && opts.cursor != "" && !validator.HasErrors() {
+		cursor, err := opts.cursorSigner.Decode(opts.cursor)
+
+		switch {
+		case err != nil:
+			validator.AddError(CursorKey, "Must be a valid cursor.")
+		case cursor.Sort != opts.sort:
+			validator.AddError(CursorKey, "Must have been issued for the same sort.")
+		case cursor.Scope != opts.cursorScope:
+			validator.AddError(CursorKey, "Must have been issued for the same endpoint and filters.")
+		}
+	}
 }
 
 func (opts Options) Page() int {
@@ -57,30 +112,89 @@ func (opts Options) PageSize() int {
 }
 
 func (opts Options) SortColumn() string {
-	if opts.isSortSafe() {
-		return strings.TrimSuffix(opts.sort, sortDescendingSuffix)
+	return opts.SortFields()[0].Column
+}
+
+func (opts Options) IsSortDescending() bool {
+	return opts.SortFields()[0].IsDescending
+}
+
+func (opts Options) SortFields() []SortField {
+	if !opts.isSortSafe() {
+		panic("unsafe sort parameter: " + opts.sort)
 	}
 
-	panic("unsafe sort parameter: " + opts.sort)
+	keys := []SortField{}
+
+	for _, key := range opts.sortKeys() {
+		keys = append(keys, parseSortField(key))
+	}
+
+	return keys
 }
 
-func (opts Options) IsSortDescending() bool {
-	return strings.HasSuffix(opts.sort, sortDescendingSuffix)
+func (opts Options) HasSortColumn(column string) bool {
+	for _, key := range opts.SortFields() {
+		if key.Column == column {
+			return true
+		}
+	}
+
+	return false
 }
 
 func (opts Options) Limit() int {
+	if opts.isCursorMode {
+		return opts.pageSize + 1
+	}
+
 	return opts.pageSize
 }
 
 func (opts Options) Offset() int {
+	if opts.isCursorMode {
+		return 0
+	}
+
 	return (opts.page - 1) * opts.pageSize
 }
 
+func (opts Options) IsCursorMode() bool {
+	return opts.isCursorMode
+}
+
+func (opts Options) IncludeTotal() bool {
+	return opts.includeTotal
+}
+
+func (opts Options) Cursor() (Cursor, bool) {
+	if !opts.isCursorMode || opts.cursor == "" {
+		return Cursor{}, false
+	}
+
+	cursor, err := opts.cursorSigner.Decode(opts.cursor)
+	if err != nil {
+		panic("invalid cursor: " + opts.cursor)
+	}
+
+	return cursor, true
+}
+
 func (opts Options) isSortSafe() bool {
-	for _, safeSort := range opts.sortSafelist {
-		safeSort = strings.ToLower(safeSort)
+	for _, key := range opts.sortKeys() {
+		if !opts.isSortKeySafe(key) {
+			return false
+		}
+	}
 
-		if opts.sort == safeSort || opts.sort == safeSort+sortDescendingSuffix {
+	return true
+}
+
+func (opts Options) isSortKeySafe(key string) bool {
+	column := parseSortField(key).Column
+
+	for _, safeSort := range opts.sortSafelist {
+		if column == strings.ToLower(safeSort) {
 			return true
 		}
 	}
@@ -88,12 +202,32 @@ func (opts Options) isSortSafe() bool {
 	return false
 }
 
+func (opts Options) sortKeys() []string {
+	return strings.Split(opts.sort, sortKeySeparator)
+}
+
+func parseSortField(key string) SortField {
+	key = strings.TrimSpace(key)
+
+	if strings.HasPrefix(key, sortDescendingPrefix) {
+		return SortField{Column: strings.TrimPrefix(key, sortDescendingPrefix), IsDescending: true}
+	}
+
+	if strings.HasSuffix(key, sortDescendingSuffix) {
+		return SortField{Column: strings.TrimSuffix(key, sortDescendingSuffix), IsDescending: true}
+	}
+
+	return SortField{Column: key}
+}
+
 type Metadata struct {
-	CurrentPage  int `json:"current_page,omitempty"`
-	PageSize     int `json:"page_size,omitempty"`
-	FirstPage    int `json:"first_page,omitempty"`
-	LastPage     int `json:"last_page,omitempty"`
-	TotalRecords int `json:"total_records,omitempty"`
+	CurrentPage  int    `json:"current_page,omitempty"`
+	PageSize     int    `json:"page_size,omitempty"`
+	FirstPage    int    `json:"first_page,omitempty"`
+	LastPage     int    `json:"last_page,omitempty"`
+	TotalRecords int    `json:"total_records,omitempty"`
+	NextCursor   string `json:"next_cursor,omitempty"`
+	PrevCursor   string `json:"prev_cursor,omitempty"`
 }
 
 func CalculateMetadata(page, pageSize, totalRecords int) Metadata {
@@ -115,3 +249,40 @@ func CalculateMetadata(page, pageSize, totalRecords int) Metadata {
 		TotalRecords: totalRecords,
 	}
 }
+
+func CalculateCursorMetadata(opts Options, firstValues, lastValues []string, hasMore bool, totalRecords int) Metadata {
+	metadata := Metadata{PageSize: opts.pageSize}
+
+	if opts.includeTotal {
+		metadata.TotalRecords = totalRecords
+	}
+
+	if firstValues == nil || lastValues == nil {
+		return metadata
+	}
+
+	cursor, hasCursor := opts.Cursor()
+
+	hasNext := hasMore
+	hasPrev := hasCursor
+
+	if cursor.IsBackward {
+		hasNext = true
+		hasPrev = hasMore
+	}
+
+	if hasNext {
+		metadata.NextCursor = opts.cursorSigner.Encode(Cursor{Sort: opts.sort, Values: lastValues, Scope: opts.cursorScope})
+	}
+
+	if hasPrev {
+		metadata.PrevCursor = opts.cursorSigner.Encode(Cursor{
+			Sort:       opts.sort,
+			Values:     firstValues,
+			IsBackward: true,
+			Scope:      opts.cursorScope,
+		})
+	}
+
+	return metadata
+}
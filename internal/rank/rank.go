@@ -0,0 +1,106 @@
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+var ErrInvalidRange = errors.New("rank: invalid range")
+
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+func Between(prev, next string) (string, error) {
+	if !IsValid(prev) || !IsValid(next) || (next != "" && prev >= next) {
+		return "", ErrInvalidRange
+	}
+
+	var rank strings.Builder
+
+	isBoundedAbove := next != ""
+
+	for i := 0; ; i++ {
+		low := 0
+		if i < len(prev) {
+			low = strings.IndexByte(digits, prev[i])
+		}
+
+		high := len(digits)
+		if isBoundedAbove {
+			if i >= len(next) {
+				return "", ErrInvalidRange
+			}
+
+			high = strings.IndexByte(digits, next[i])
+		}
+
+		if high-low > 1 {
+			rank.WriteByte(digits[(low+high)/2])
+			return rank.String(), nil
+		}
+
+		rank.WriteByte(digits[low])
+
+		if high > low {
+			isBoundedAbove = false
+		}
+	}
+}
+
+func After(prev string) (string, error) {
+	if !IsValid(prev) {
+		return "", ErrInvalidRange
+	}
+
+	length := 0
+	if prev != "" {
+		length = strings.IndexByte(digits, prev[0])
+	}
+
+	integer := []byte(prev[min(len(prev), 1):min(len(prev), length+1)])
+	for len(integer) < length {
+		integer = append(integer, digits[0])
+	}
+
+	if increment(integer) {
+		if integer[length-1] == digits[0] {
+			increment(integer)
+		}
+
+		return string(prev[0]) + string(integer), nil
+	}
+
+	if length+1 >= len(digits) {
+		return Between(prev, "")
+	}
+
+	return string(digits[length+1]) + strings.Repeat(digits[:1], length) + digits[1:2], nil
+}
+
+func increment(integer []byte) bool {
+	for i := len(integer) - 1; i >= 0; i-- {
+		digit := strings.IndexByte(digits, integer[i]) + 1
+
+		if digit < len(digits) {
+			integer[i] = digits[digit]
+			return true
+		}
+
+		integer[i] = digits[0]
+	}
+
+	return false
+}
+
+func IsValid(rank string) bool {
+	if strings.HasSuffix(rank, digits[:1]) {
+		return false
+	}
+
+	for i := 0; i < len(rank); i++ {
+		if strings.IndexByte(digits, rank[i]) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
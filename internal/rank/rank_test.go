@@ -0,0 +1,96 @@
+package rank
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		next string
+		want string
+		err  error
+	}{
+		{name: "empty range", prev: "", next: "", want: "i"},
+		{name: "before first", prev: "", next: "11", want: "0i"},
+		{name: "after last", prev: "y", next: "", want: "z"},
+		{name: "adjacent digits", prev: "a", next: "b", want: "ai"},
+		{name: "wide gap", prev: "a", next: "c", want: "b"},
+		{name: "prefix of next", prev: "a", next: "a1", want: "a0i"},
+		{name: "equal ranks", prev: "a", next: "a", err: ErrInvalidRange},
+		{name: "reversed ranks", prev: "b", next: "a", err: ErrInvalidRange},
+		{name: "trailing zero", prev: "a0", next: "", err: ErrInvalidRange},
+		{name: "invalid character", prev: "A", next: "", err: ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Between(tt.prev, tt.next)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("Between(%q, %q) error = %v, want %v", tt.prev, tt.next, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("Between(%q, %q) = %q, want %q", tt.prev, tt.next, got, tt.want)
+			}
+
+			if tt.err == nil && (got <= tt.prev || (tt.next != "" && got >= tt.next) || !IsValid(got)) {
+				t.Fatalf("Between(%q, %q) = %q is not a valid rank within the range", tt.prev, tt.next, got)
+			}
+		})
+	}
+}
+
+func TestAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		prev string
+		want string
+		err  error
+	}{
+		{name: "empty column", prev: "", want: "11"},
+		{name: "increment", prev: "11", want: "12"},
+		{name: "skips trailing zero", prev: "29z", want: "2a1"},
+		{name: "widens integer part", prev: "1z", want: "201"},
+		{name: "drops fractional part", prev: "12i", want: "13"},
+		{name: "pads short integer part", prev: "5", want: "500001"},
+		{name: "legacy rank", prev: "0000000001i", want: "11"},
+		{name: "invalid rank", prev: "a0", err: ErrInvalidRange},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := After(tt.prev)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("After(%q) error = %v, want %v", tt.prev, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("After(%q) = %q, want %q", tt.prev, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAfterKeepsRanksShort(t *testing.T) {
+	prev := ""
+
+	for i := 0; i < 100_000; i++ {
+		next, err := After(prev)
+		if err != nil {
+			t.Fatalf("After(%q) error = %v", prev, err)
+		}
+
+		if next <= prev || !IsValid(next) {
+			t.Fatalf("After(%q) = %q is not a valid rank after the previous one", prev, next)
+		}
+
+		prev = next
+	}
+
+	if len(prev) > 5 {
+		t.Fatalf("rank after 100000 appends is %d characters long", len(prev))
+	}
+}
@@ -0,0 +1,197 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+)
+
+type AttachmentRepository struct {
+	DB *sql.DB
+}
+
+func (r *AttachmentRepository) Insert(
+	ctx context.Context,
+	attachment *models.Attachment,
+	taskID, uploaderID int64,
+) error {
+	query := `
+	INSERT INTO attachments (filename, content_type, size, storage_key, task_id, uploader_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at
+	`
+
+	args := []any{
+		attachment.Filename,
+		attachment.ContentType,
+		attachment.Size,
+		attachment.StorageKey,
+		taskID,
+		uploaderID,
+	}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&attachment.ID, &attachment.CreatedAt); err != nil {
+		return err
+	}
+
+	attachment.TaskID = taskID
+
+	return nil
+}
+
+func (r *AttachmentRepository) GetByID(ctx context.Context, attachmentID, taskID int64) (*models.Attachment, error) {
+	query := `
+	SELECT
+		attachments.id,
+		attachments.created_at,
+		attachments.filename,
+		attachments.content_type,
+		attachments.size,
+		attachments.storage_key,
+		attachments.task_id,
+		uploader.id, uploader.username, uploader.email, uploader.is_verified
+	FROM attachments
+	INNER JOIN users AS uploader ON uploader.id = attachments.uploader_id
+	WHERE attachments.id = $1 AND attachments.task_id = $2
+	`
+
+	var attachment models.Attachment
+	attachment.Uploader = &models.User{}
+
+	err := r.DB.QueryRowContext(ctx, query, attachmentID, taskID).Scan(
+		&attachment.ID,
+		&attachment.CreatedAt,
+		&attachment.Filename,
+		&attachment.ContentType,
+		&attachment.Size,
+		&attachment.StorageKey,
+		&attachment.TaskID,
+		&attachment.Uploader.ID, &attachment.Uploader.Username, &attachment.Uploader.Email, &attachment.Uploader.IsVerified,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetAll(
+	ctx context.Context,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Attachment, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT
+			count(*) OVER(),
+			attachments.id,
+			attachments.created_at,
+			attachments.filename,
+			attachments.content_type,
+			attachments.size,
+			uploader.username, uploader.email, uploader.is_verified
+		FROM attachments
+		INNER JOIN users AS uploader ON uploader.id = attachments.uploader_id
+		WHERE attachments.task_id = $1
+		ORDER BY attachments.%s %s, attachments.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{taskID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	attachments := []*models.Attachment{}
+
+	for rows.Next() {
+		var attachment models.Attachment
+		attachment.Uploader = &models.User{}
+
+		err := rows.Scan(
+			&totalRecords,
+			&attachment.ID,
+			&attachment.CreatedAt,
+			&attachment.Filename,
+			&attachment.ContentType,
+			&attachment.Size,
+			&attachment.Uploader.Username, &attachment.Uploader.Email, &attachment.Uploader.IsVerified,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		attachments = append(attachments, &attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return attachments, metadata, nil
+}
+
+func (r *AttachmentRepository) Delete(ctx context.Context, attachmentID int64) error {
+	query := `
+	DELETE FROM attachments
+	WHERE id = $1
+	`
+
+	return delete(ctx, r.DB, query, attachmentID)
+}
+
+func (r *AttachmentRepository) GetPendingBlobDeletions(ctx context.Context, limit int) ([]string, error) {
+	query := `
+	SELECT storage_key
+	FROM attachment_blob_deletions
+	ORDER BY created_at ASC
+	LIMIT $1
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	storageKeys := []string{}
+
+	for rows.Next() {
+		var storageKey string
+
+		if err := rows.Scan(&storageKey); err != nil {
+			return nil, err
+		}
+
+		storageKeys = append(storageKeys, storageKey)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return storageKeys, nil
+}
+
+func (r *AttachmentRepository) DeletePendingBlobDeletions(ctx context.Context, storageKeys []string) error {
+	query := `
+	DELETE FROM attachment_blob_deletions
+	WHERE storage_key = ANY($1)
+	`
+
+	_, err := r.DB.ExecContext(ctx, query, pq.Array(storageKeys))
+	return err
+}
@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type CommentRepository struct {
+	DB *sql.DB
+}
+
+func (r *CommentRepository) Insert(ctx context.Context, comment *models.Comment, taskID, authorID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		INSERT INTO comments (body, task_id, author_id, parent_id)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at, version
+		`
+
+		args := []any{comment.Body, taskID, authorID, comment.ParentID}
+
+		err := tx.QueryRowContext(ctx, query, args...).Scan(
+			&comment.ID,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		comment.TaskID = taskID
+
+		return r.setMentions(ctx, tx, comment, authorID)
+	})
+}
+
+func (r *CommentRepository) GetByID(ctx context.Context, commentID, taskID int64) (*models.Comment, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT
+			comments.id,
+			comments.created_at,
+			comments.updated_at,
+			comments.body,
+			comments.parent_id,
+			comments.task_id,
+			comments.version,
+			(SELECT count(*) FROM comments AS replies WHERE replies.parent_id = comments.id),
+			author.id, author.username, author.email, author.is_verified,
+			%s
+		FROM comments
+		INNER JOIN users AS author ON author.id = comments.author_id
+		WHERE comments.id = $1 AND comments.task_id = $2
+		`,
+		r.mentionsColumn(),
+	)
+
+	var comment models.Comment
+	comment.Author = &models.User{}
+
+	var mentions []byte
+
+	err := r.DB.QueryRowContext(ctx, query, commentID, taskID).Scan(
+		&comment.ID,
+		&comment.CreatedAt,
+		&comment.UpdatedAt,
+		&comment.Body,
+		&comment.ParentID,
+		&comment.TaskID,
+		&comment.Version,
+		&comment.ReplyCount,
+		&comment.Author.ID, &comment.Author.Username, &comment.Author.Email, &comment.Author.IsVerified,
+		&mentions,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	if comment.Mentions, err = unmarshalUsers(mentions); err != nil {
+		return nil, err
+	}
+
+	return &comment, nil
+}
+
+func (r *CommentRepository) GetAll(
+	ctx context.Context,
+	filters models.CommentFilters,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Comment, pagination.Metadata, error) {
+	filterByParentCondition := "AND comments.parent_id IS NULL"
+
+	args := []any{taskID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	if filters.ParentID != nil {
+		filterByParentCondition = fmt.Sprintf("AND comments.parent_id = $%d", len(args)+1)
+		args = append(args, *filters.ParentID)
+	}
+
+	query := fmt.Sprintf(
+		`
+		SELECT
+			count(*) OVER(),
+			comments.id,
+			comments.created_at,
+			comments.updated_at,
+			comments.body,
+			comments.parent_id,
+			(SELECT count(*) FROM comments AS replies WHERE replies.parent_id = comments.id),
+			author.username, author.email, author.is_verified,
+			%s
+		FROM comments
+		INNER JOIN users AS author ON author.id = comments.author_id
+		WHERE comments.task_id = $1 %s
+		ORDER BY comments.%s %s, comments.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		r.mentionsColumn(),
+		filterByParentCondition,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	comments := []*models.Comment{}
+
+	for rows.Next() {
+		var comment models.Comment
+		comment.Author = &models.User{}
+
+		var mentions []byte
+
+		err := rows.Scan(
+			&totalRecords,
+			&comment.ID,
+			&comment.CreatedAt,
+			&comment.UpdatedAt,
+			&comment.Body,
+			&comment.ParentID,
+			&comment.ReplyCount,
+			&comment.Author.Username, &comment.Author.Email, &comment.Author.IsVerified,
+			&mentions,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if comment.Mentions, err = unmarshalUsers(mentions); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		comments = append(comments, &comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return comments, metadata, nil
+}
+
+func (r *CommentRepository) Update(ctx context.Context, comment *models.Comment) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE comments
+		SET body = $1, updated_at = NOW(), version = version + 1
+		WHERE id = $2 AND version = $3
+		RETURNING updated_at, version
+		`
+
+		args := []any{comment.Body, comment.ID, comment.Version}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&comment.UpdatedAt, &comment.Version); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return repositories.ErrEditConflict
+			default:
+				return err
+			}
+		}
+
+		return r.setMentions(ctx, tx, comment, comment.Author.ID)
+	})
+}
+
+func (r *CommentRepository) Delete(ctx context.Context, commentID int64) error {
+	query := `
+	DELETE FROM comments
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, commentID)
+	return err
+}
+
+func (r *CommentRepository) setMentions(ctx context.Context, tx *sql.Tx, comment *models.Comment, actorID int64) error {
+	return setMentions(
+		ctx,
+		tx,
+		"comment_mentions",
+		"comment_id",
+		comment.ID,
+		comment.Mentions,
+		actorID,
+		comment.TaskID,
+		&comment.ID,
+	)
+}
+
+func (r *CommentRepository) mentionsColumn() string {
+	return `
+	(
+		SELECT coalesce(
+			json_agg(
+				json_build_object(
+					'id', users.id,
+					'username', users.username,
+					'email', users.email,
+					'is_verified', users.is_verified
+				)
+				ORDER BY users.username
+			),
+			'[]'
+		)
+		FROM comment_mentions
+		INNER JOIN users ON users.id = comment_mentions.mentioned_id
+		WHERE comment_mentions.comment_id = comments.id
+	)
+	`
+}
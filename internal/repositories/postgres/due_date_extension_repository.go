@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type DueDateExtensionRepository struct {
+	DB *sql.DB
+}
+
+func (r *DueDateExtensionRepository) Insert(
+	ctx context.Context,
+	extension *models.DueDateExtension,
+	taskID, requesterID int64,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		INSERT INTO due_date_extensions (previous_due, proposed_due, reason, task_id, requester_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id, created_at, status, version
+		`
+
+		args := []any{extension.PreviousDue, extension.ProposedDue, extension.Reason, taskID, requesterID}
+
+		err := tx.QueryRowContext(ctx, query, args...).Scan(
+			&extension.ID,
+			&extension.CreatedAt,
+			&extension.Status,
+			&extension.Version,
+		)
+		if err != nil {
+			switch {
+			case isDuplicateKeyError(err, "due_date_extensions_pending_task_id_key"):
+				return repositories.ErrPendingExtensionExists
+			default:
+				return err
+			}
+		}
+
+		extension.TaskID = taskID
+
+		change := models.TaskChange{
+			Field:    "due",
+			OldValue: extension.PreviousDue.Format(time.RFC3339),
+			NewValue: extension.ProposedDue.Format(time.RFC3339),
+		}
+
+		return insertTaskEvent(ctx, tx, taskID, requesterID, models.TaskEventActionExtensionRequested, change)
+	})
+}
+
+func (r *DueDateExtensionRepository) GetByID(
+	ctx context.Context,
+	extensionID, taskID int64,
+) (*models.DueDateExtension, error) {
+	query := `
+	SELECT
+		due_date_extensions.id,
+		due_date_extensions.created_at,
+		due_date_extensions.previous_due,
+		due_date_extensions.proposed_due,
+		due_date_extensions.reason,
+		due_date_extensions.status,
+		due_date_extensions.decided_at,
+		due_date_extensions.decision_note,
+		due_date_extensions.task_id,
+		due_date_extensions.version,
+		requester.id, requester.username, requester.email, requester.is_verified,
+		decider.username, decider.email, decider.is_verified
+	FROM due_date_extensions
+	INNER JOIN users AS requester ON requester.id = due_date_extensions.requester_id
+	LEFT JOIN users AS decider ON decider.id = due_date_extensions.decider_id
+	WHERE due_date_extensions.id = $1 AND due_date_extensions.task_id = $2
+	`
+
+	var extension models.DueDateExtension
+	extension.Requester = &models.User{}
+
+	var decider nullableUser
+
+	err := r.DB.QueryRowContext(ctx, query, extensionID, taskID).Scan(
+		&extension.ID,
+		&extension.CreatedAt,
+		&extension.PreviousDue,
+		&extension.ProposedDue,
+		&extension.Reason,
+		&extension.Status,
+		&extension.DecidedAt,
+		&extension.DecisionNote,
+		&extension.TaskID,
+		&extension.Version,
+		&extension.Requester.ID,
+		&extension.Requester.Username,
+		&extension.Requester.Email,
+		&extension.Requester.IsVerified,
+		&decider.username, &decider.email, &decider.isVerified,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	extension.Decider = decider.toUser()
+
+	return &extension, nil
+}
+
+func (r *DueDateExtensionRepository) GetAll(
+	ctx context.Context,
+	filters models.DueDateExtensionFilters,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.DueDateExtension, pagination.Metadata, error) {
+	var filterByStatusCondition string
+
+	args := []any{taskID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	if filters.Status != nil {
+		filterByStatusCondition = fmt.Sprintf("AND due_date_extensions.status = $%d", len(args)+1)
+		args = append(args, *filters.Status)
+	}
+
+	query := fmt.Sprintf(
+		`
+		SELECT
+			count(*) OVER(),
+			due_date_extensions.id,
+			due_date_extensions.created_at,
+			due_date_extensions.previous_due,
+			due_date_extensions.proposed_due,
+			due_date_extensions.reason,
+			due_date_extensions.status,
+			due_date_extensions.decided_at,
+			due_date_extensions.decision_note,
+			due_date_extensions.task_id,
+			requester.username, requester.email, requester.is_verified,
+			decider.username, decider.email, decider.is_verified
+		FROM due_date_extensions
+		INNER JOIN users AS requester ON requester.id = due_date_extensions.requester_id
+		LEFT JOIN users AS decider ON decider.id = due_date_extensions.decider_id
+		WHERE due_date_extensions.task_id = $1 %s
+		ORDER BY due_date_extensions.%s %s, due_date_extensions.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		filterByStatusCondition,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	extensions := []*models.DueDateExtension{}
+
+	for rows.Next() {
+		var extension models.DueDateExtension
+		extension.Requester = &models.User{}
+
+		var decider nullableUser
+
+		err := rows.Scan(
+			&totalRecords,
+			&extension.ID,
+			&extension.CreatedAt,
+			&extension.PreviousDue,
+			&extension.ProposedDue,
+			&extension.Reason,
+			&extension.Status,
+			&extension.DecidedAt,
+			&extension.DecisionNote,
+			&extension.TaskID,
+			&extension.Requester.Username, &extension.Requester.Email, &extension.Requester.IsVerified,
+			&decider.username, &decider.email, &decider.isVerified,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		extension.Decider = decider.toUser()
+
+		extensions = append(extensions, &extension)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return extensions, metadata, nil
+}
+
+func (r *DueDateExtensionRepository) Decide(
+	ctx context.Context,
+	extension *models.DueDateExtension,
+	task *models.Task,
+	decider *models.User,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE due_date_extensions
+		SET status = $1, decision_note = $2, decider_id = $3, decided_at = NOW(), version = version + 1
+		WHERE id = $4 AND version = $5 AND status = $6
+		RETURNING decided_at, version
+		`
+
+		args := []any{
+			extension.Status,
+			extension.DecisionNote,
+			decider.ID,
+			extension.ID,
+			extension.Version,
+			models.ExtensionStatusPending,
+		}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&extension.DecidedAt, &extension.Version); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return repositories.ErrEditConflict
+			default:
+				return err
+			}
+		}
+
+		extension.Decider = decider
+
+		action := models.TaskEventActionExtensionRejected
+
+		if extension.Status == models.ExtensionStatusApproved {
+			action = models.TaskEventActionExtensionApproved
+
+			query := `
+			UPDATE tasks
+			SET due = $1, version = version + 1
+			WHERE id = $2 AND version = $3
+			RETURNING version
+			`
+
+			err := tx.QueryRowContext(ctx, query, extension.ProposedDue, task.ID, task.Version).Scan(&task.Version)
+			if err != nil {
+				return handleTaskUpdateError(err)
+			}
+
+			task.Due = extension.ProposedDue
+		}
+
+		change := models.TaskChange{
+			Field:    "due",
+			OldValue: extension.PreviousDue.Format(time.RFC3339),
+			NewValue: extension.ProposedDue.Format(time.RFC3339),
+		}
+
+		return insertTaskEvent(ctx, tx, task.ID, decider.ID, action, change)
+	})
+}
+
+type nullableUser struct {
+	username   *string
+	email      *string
+	isVerified *bool
+}
+
+func (u nullableUser) toUser() *models.User {
+	if u.username == nil {
+		return nil
+	}
+
+	return &models.User{Username: *u.username, Email: *u.email, IsVerified: *u.isVerified}
+}
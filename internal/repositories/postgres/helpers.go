@@ -2,12 +2,20 @@ package postgres
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 
+	"github.com/svetoslaven/tasktracker/internal/models"
 	"github.com/svetoslaven/tasktracker/internal/repositories"
 )
 
-func delete(ctx context.Context, db *sql.DB, query string, args ...any) error {
+type userRecord struct {
+	ID         int64  `json:"id"`
+	Username   string `json:"username"`
+	Email      string `json:"email"`
+	IsVerified bool   `json:"is_verified"`
+}
+
+func delete(ctx context.Context, db dbExecutor, query string, args ...any) error {
 	result, err := db.ExecContext(ctx, query, args...)
 	if err != nil {
 		return err
@@ -24,3 +32,22 @@ func delete(ctx context.Context, db *sql.DB, query string, args ...any) error {
 
 	return nil
 }
+
+func unmarshalUsers(data []byte) ([]*models.User, error) {
+	var records []userRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, err
+	}
+
+	users := make([]*models.User, 0, len(records))
+	for _, record := range records {
+		users = append(users, &models.User{
+			ID:         record.ID,
+			Username:   record.Username,
+			Email:      record.Email,
+			IsVerified: record.IsVerified,
+		})
+	}
+
+	return users, nil
+}
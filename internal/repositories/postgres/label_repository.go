@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type LabelRepository struct {
+	DB *sql.DB
+}
+
+func (r *LabelRepository) Insert(ctx context.Context, label *models.Label, teamID int64) error {
+	query := `
+	INSERT INTO labels (name, color, team_id)
+	VALUES ($1, $2, $3)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{label.Name, label.Color, teamID}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&label.ID, &label.CreatedAt, &label.Version); err != nil {
+		switch {
+		case r.isDuplicateLabelNameError(err):
+			return repositories.ErrDuplicateLabelName
+		default:
+			return err
+		}
+	}
+
+	label.TeamID = teamID
+
+	return nil
+}
+
+func (r *LabelRepository) GetByID(ctx context.Context, labelID, teamID int64) (*models.Label, error) {
+	query := `
+	SELECT id, created_at, name, color, team_id, version
+	FROM labels
+	WHERE id = $1 AND team_id = $2
+	`
+
+	var label models.Label
+
+	err := r.DB.QueryRowContext(ctx, query, labelID, teamID).Scan(
+		&label.ID,
+		&label.CreatedAt,
+		&label.Name,
+		&label.Color,
+		&label.TeamID,
+		&label.Version,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return &label, nil
+}
+
+func (r *LabelRepository) GetAllByNames(ctx context.Context, names []string, teamID int64) ([]*models.Label, error) {
+	query := `
+	SELECT id, created_at, name, color, team_id, version
+	FROM labels
+	WHERE team_id = $1 AND name = ANY($2)
+	ORDER BY name ASC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, teamID, pq.Array(names))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	labels := []*models.Label{}
+
+	for rows.Next() {
+		var label models.Label
+
+		err := rows.Scan(&label.ID, &label.CreatedAt, &label.Name, &label.Color, &label.TeamID, &label.Version)
+		if err != nil {
+			return nil, err
+		}
+
+		labels = append(labels, &label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return labels, nil
+}
+
+func (r *LabelRepository) GetAll(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Label, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), id, created_at, name, color, team_id, version
+		FROM labels
+		WHERE team_id = $1
+		ORDER BY %s %s, id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{teamID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	labels := []*models.Label{}
+
+	for rows.Next() {
+		var label models.Label
+
+		err := rows.Scan(
+			&totalRecords,
+			&label.ID,
+			&label.CreatedAt,
+			&label.Name,
+			&label.Color,
+			&label.TeamID,
+			&label.Version,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		labels = append(labels, &label)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return labels, metadata, nil
+}
+
+func (r *LabelRepository) Update(ctx context.Context, label *models.Label) error {
+	query := `
+	UPDATE labels
+	SET name = $1, color = $2, version = version + 1
+	WHERE id = $3 AND version = $4
+	RETURNING version
+	`
+
+	args := []any{label.Name, label.Color, label.ID, label.Version}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&label.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		case r.isDuplicateLabelNameError(err):
+			return repositories.ErrDuplicateLabelName
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *LabelRepository) Delete(ctx context.Context, labelID int64) error {
+	query := `
+	DELETE FROM labels
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, labelID)
+	return err
+}
+
+func (r *LabelRepository) isDuplicateLabelNameError(err error) bool {
+	return isDuplicateKeyError(err, "labels_team_id_name_key")
+}
@@ -0,0 +1,376 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type MilestoneRepository struct {
+	DB *sql.DB
+}
+
+type estimateProgressRecord struct {
+	Unit      int     `json:"unit"`
+	Completed float64 `json:"completed"`
+	Total     float64 `json:"total"`
+}
+
+func (r *MilestoneRepository) Insert(ctx context.Context, milestone *models.Milestone, teamID int64) error {
+	query := `
+	INSERT INTO milestones (name, starts_at, ends_at, state, team_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{milestone.Name, milestone.StartsAt, milestone.EndsAt, milestone.State, teamID}
+
+	err := r.DB.QueryRowContext(ctx, query, args...).Scan(&milestone.ID, &milestone.CreatedAt, &milestone.Version)
+	if err != nil {
+		switch {
+		case r.isDuplicateMilestoneNameError(err):
+			return repositories.ErrDuplicateMilestoneName
+		default:
+			return err
+		}
+	}
+
+	milestone.TeamID = teamID
+	milestone.Progress = &models.MilestoneProgress{Estimates: []*models.EstimateProgress{}}
+
+	return nil
+}
+
+func (r *MilestoneRepository) GetByID(ctx context.Context, milestoneID, teamID int64) (*models.Milestone, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		%s
+		WHERE milestones.id = $1 AND milestones.team_id = $2
+		`,
+		r.columns(),
+		r.fromClause(),
+	)
+
+	milestone, estimates := r.newMilestone()
+
+	err := r.DB.QueryRowContext(ctx, query, milestoneID, teamID).Scan(r.scanTargets(milestone, estimates)...)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	if err := r.unmarshalEstimates(*estimates, milestone); err != nil {
+		return nil, err
+	}
+
+	return milestone, nil
+}
+
+func (r *MilestoneRepository) GetAll(
+	ctx context.Context,
+	filters models.MilestoneFilters,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Milestone, pagination.Metadata, error) {
+	args := []any{teamID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	var filterByStateCondition string
+
+	if len(filters.States) > 0 {
+		filterByStateCondition = fmt.Sprintf("AND milestones.state = ANY($%d)", len(args)+1)
+		args = append(args, pq.Array(filters.States))
+	}
+
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), %s
+		%s
+		WHERE milestones.team_id = $1 %s
+		ORDER BY milestones.%s %s, milestones.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		r.columns(),
+		r.fromClause(),
+		filterByStateCondition,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	milestones := []*models.Milestone{}
+
+	for rows.Next() {
+		milestone, estimates := r.newMilestone()
+
+		if err := rows.Scan(append([]any{&totalRecords}, r.scanTargets(milestone, estimates)...)...); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if err := r.unmarshalEstimates(*estimates, milestone); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		milestones = append(milestones, milestone)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return milestones, metadata, nil
+}
+
+func (r *MilestoneRepository) Update(ctx context.Context, milestone *models.Milestone) error {
+	query := `
+	UPDATE milestones
+	SET name = $1, starts_at = $2, ends_at = $3, version = version + 1
+	WHERE id = $4 AND version = $5
+	RETURNING version
+	`
+
+	args := []any{milestone.Name, milestone.StartsAt, milestone.EndsAt, milestone.ID, milestone.Version}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&milestone.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		case r.isDuplicateMilestoneNameError(err):
+			return repositories.ErrDuplicateMilestoneName
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *MilestoneRepository) Close(
+	ctx context.Context,
+	milestone *models.Milestone,
+	nextMilestoneID *int64,
+	closerID int64,
+) (int, error) {
+	movedTasks := 0
+
+	err := runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE milestones
+		SET state = $1, closed_at = NOW(), version = version + 1
+		WHERE id = $2 AND version = $3 AND state = $4
+		RETURNING closed_at, version
+		`
+
+		args := []any{models.MilestoneStateClosed, milestone.ID, milestone.Version, models.MilestoneStateOpen}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&milestone.ClosedAt, &milestone.Version); err != nil {
+			switch {
+			case errors.Is(err, sql.ErrNoRows):
+				return repositories.ErrEditConflict
+			default:
+				return err
+			}
+		}
+
+		milestone.State = models.MilestoneStateClosed
+
+		if nextMilestoneID != nil {
+			query := `
+			SELECT id
+			FROM milestones
+			WHERE id = $1 AND team_id = $2 AND state = $3
+			FOR SHARE
+			`
+
+			args := []any{*nextMilestoneID, milestone.TeamID, models.MilestoneStateOpen}
+
+			if err := tx.QueryRowContext(ctx, query, args...).Scan(new(int64)); err != nil {
+				switch {
+				case errors.Is(err, sql.ErrNoRows):
+					return repositories.ErrEditConflict
+				default:
+					return err
+				}
+			}
+		}
+
+		moveQuery := `
+		UPDATE tasks
+		SET milestone_id = $1, version = version + 1
+		FROM workflow_statuses AS statuses
+		WHERE statuses.id = tasks.status_id
+			AND tasks.milestone_id = $2
+			AND tasks.deleted_at IS NULL
+			AND statuses.category != ALL($3)
+		RETURNING tasks.id
+		`
+
+		finishedCategories := []models.StatusCategory{models.StatusCategoryDone, models.StatusCategoryCancelled}
+
+		rows, err := tx.QueryContext(ctx, moveQuery, nextMilestoneID, milestone.ID, pq.Array(finishedCategories))
+		if err != nil {
+			return err
+		}
+
+		defer rows.Close()
+
+		var taskIDs []int64
+
+		for rows.Next() {
+			var taskID int64
+
+			if err := rows.Scan(&taskID); err != nil {
+				return err
+			}
+
+			taskIDs = append(taskIDs, taskID)
+		}
+
+		if err := rows.Err(); err != nil {
+			return err
+		}
+
+		change := models.TaskChange{Field: "milestone", OldValue: strconv.FormatInt(milestone.ID, 10)}
+		if nextMilestoneID != nil {
+			change.NewValue = strconv.FormatInt(*nextMilestoneID, 10)
+		}
+
+		for _, taskID := range taskIDs {
+			if err := insertTaskEvent(ctx, tx, taskID, closerID, models.TaskEventActionUpdated, change); err != nil {
+				return err
+			}
+		}
+
+		movedTasks = len(taskIDs)
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return movedTasks, nil
+}
+
+func (r *MilestoneRepository) Delete(ctx context.Context, milestoneID int64) error {
+	query := `
+	DELETE FROM milestones
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, milestoneID)
+	return err
+}
+
+func (r *MilestoneRepository) columns() string {
+	return `
+	milestones.id,
+	milestones.created_at,
+	milestones.name,
+	milestones.starts_at,
+	milestones.ends_at,
+	milestones.state,
+	milestones.closed_at,
+	milestones.team_id,
+	milestones.version,
+	progress.completed, progress.total,
+	progress.estimates
+	`
+}
+
+func (r *MilestoneRepository) fromClause() string {
+	return fmt.Sprintf(
+		`
+		FROM milestones
+		LEFT JOIN LATERAL (
+			SELECT
+				coalesce(sum(units.completed), 0)::integer AS completed,
+				coalesce(sum(units.total), 0)::integer AS total,
+				coalesce(
+					json_agg(
+						json_build_object(
+							'unit', units.estimate_unit,
+							'completed', units.completed_estimate,
+							'total', units.total_estimate
+						)
+						ORDER BY units.estimate_unit
+					) FILTER (WHERE units.estimate_unit IS NOT NULL),
+					'[]'
+				) AS estimates
+			FROM (
+				SELECT
+					tasks.estimate_unit,
+					count(*) FILTER (WHERE statuses.category = %[1]d) AS completed,
+					count(*) AS total,
+					coalesce(sum(tasks.estimate) FILTER (WHERE statuses.category = %[1]d), 0) AS completed_estimate,
+					coalesce(sum(tasks.estimate), 0) AS total_estimate
+				FROM tasks
+				INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+				WHERE tasks.milestone_id = milestones.id
+					AND tasks.deleted_at IS NULL
+					AND statuses.category != %[2]d
+				GROUP BY tasks.estimate_unit
+			) AS units
+		) AS progress ON true
+		`,
+		models.StatusCategoryDone,
+		models.StatusCategoryCancelled,
+	)
+}
+
+func (r *MilestoneRepository) newMilestone() (*models.Milestone, *[]byte) {
+	return &models.Milestone{Progress: &models.MilestoneProgress{}}, new([]byte)
+}
+
+func (r *MilestoneRepository) scanTargets(milestone *models.Milestone, estimates *[]byte) []any {
+	return []any{
+		&milestone.ID,
+		&milestone.CreatedAt,
+		&milestone.Name,
+		&milestone.StartsAt,
+		&milestone.EndsAt,
+		&milestone.State,
+		&milestone.ClosedAt,
+		&milestone.TeamID,
+		&milestone.Version,
+		&milestone.Progress.CompletedTasks, &milestone.Progress.TotalTasks,
+		estimates,
+	}
+}
+
+func (r *MilestoneRepository) unmarshalEstimates(data []byte, milestone *models.Milestone) error {
+	var records []estimateProgressRecord
+
+	if err := json.Unmarshal(data, &records); err != nil {
+		return err
+	}
+
+	milestone.Progress.Estimates = make([]*models.EstimateProgress, 0, len(records))
+	for _, record := range records {
+		milestone.Progress.Estimates = append(milestone.Progress.Estimates, &models.EstimateProgress{
+			Unit:      models.EstimateUnit(record.Unit),
+			Completed: record.Completed,
+			Total:     record.Total,
+		})
+	}
+
+	return nil
+}
+
+func (r *MilestoneRepository) isDuplicateMilestoneNameError(err error) bool {
+	return isDuplicateKeyError(err, "milestones_team_id_name_key")
+}
@@ -0,0 +1,245 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type NotificationRepository struct {
+	DB *sql.DB
+}
+
+func (r *NotificationRepository) GetByID(
+	ctx context.Context,
+	notificationID, recipientID int64,
+) (*models.Notification, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		%s
+		WHERE notifications.id = $1 AND notifications.recipient_id = $2
+		`,
+		r.columns(),
+		r.fromClause(),
+	)
+
+	notification := r.newNotification()
+
+	err := r.DB.QueryRowContext(ctx, query, notificationID, recipientID).Scan(r.scanTargets(notification)...)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return notification, nil
+}
+
+func (r *NotificationRepository) GetAll(
+	ctx context.Context,
+	filters models.NotificationFilters,
+	recipientID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Notification, pagination.Metadata, error) {
+	var filterByReadCondition string
+
+	if filters.IsRead != nil {
+		if *filters.IsRead {
+			filterByReadCondition = "AND notifications.read_at IS NOT NULL"
+		} else {
+			filterByReadCondition = "AND notifications.read_at IS NULL"
+		}
+	}
+
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), %s
+		%s
+		WHERE notifications.recipient_id = $1 %s
+		ORDER BY notifications.%s %s, notifications.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		r.columns(),
+		r.fromClause(),
+		filterByReadCondition,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, recipientID, paginationOpts.Limit(), paginationOpts.Offset())
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	notifications := []*models.Notification{}
+
+	for rows.Next() {
+		notification := r.newNotification()
+
+		if err := rows.Scan(append([]any{&totalRecords}, r.scanTargets(notification)...)...); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return notifications, metadata, nil
+}
+
+func (r *NotificationRepository) MarkAsRead(ctx context.Context, notification *models.Notification) error {
+	query := `
+	UPDATE notifications
+	SET read_at = NOW()
+	WHERE id = $1 AND read_at IS NULL
+	RETURNING read_at
+	`
+
+	if err := r.DB.QueryRowContext(ctx, query, notification.ID).Scan(&notification.ReadAt); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func setMentions(
+	ctx context.Context,
+	db dbExecutor,
+	table, ownerColumn string,
+	ownerID int64,
+	mentions []*models.User,
+	actorID, taskID int64,
+	commentID *int64,
+) error {
+	mentionedIDs := make([]int64, 0, len(mentions))
+	for _, mentioned := range mentions {
+		mentionedIDs = append(mentionedIDs, mentioned.ID)
+	}
+
+	deleteQuery := fmt.Sprintf(
+		`
+		DELETE FROM %[1]s
+		WHERE %[2]s = $1 AND NOT (mentioned_id = ANY($2))
+		`,
+		table,
+		ownerColumn,
+	)
+
+	if _, err := db.ExecContext(ctx, deleteQuery, ownerID, pq.Array(mentionedIDs)); err != nil {
+		return err
+	}
+
+	insertQuery := fmt.Sprintf(
+		`
+		INSERT INTO %[1]s (%[2]s, mentioned_id)
+		SELECT $1, unnest($2::bigint[])
+		ON CONFLICT DO NOTHING
+		RETURNING mentioned_id
+		`,
+		table,
+		ownerColumn,
+	)
+
+	rows, err := db.QueryContext(ctx, insertQuery, ownerID, pq.Array(mentionedIDs))
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var recipientIDs []int64
+
+	for rows.Next() {
+		var mentionedID int64
+
+		if err := rows.Scan(&mentionedID); err != nil {
+			return err
+		}
+
+		if mentionedID != actorID {
+			recipientIDs = append(recipientIDs, mentionedID)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(recipientIDs) == 0 {
+		return nil
+	}
+
+	notificationQuery := `
+	INSERT INTO notifications (kind, recipient_id, actor_id, task_id, comment_id)
+	SELECT $1, unnest($2::bigint[]), $3, $4, $5
+	`
+
+	args := []any{models.NotificationKindMention, pq.Array(recipientIDs), actorID, taskID, commentID}
+
+	_, err = db.ExecContext(ctx, notificationQuery, args...)
+	return err
+}
+
+func (r *NotificationRepository) columns() string {
+	return `
+	notifications.id,
+	notifications.created_at,
+	notifications.kind,
+	actor.id, actor.username, actor.email, actor.is_verified,
+	teams.name,
+	tasks.id, tasks.title,
+	statuses.id, statuses.name, statuses.category,
+	notifications.comment_id,
+	notifications.read_at
+	`
+}
+
+func (r *NotificationRepository) fromClause() string {
+	return `
+	FROM notifications
+	INNER JOIN users AS actor ON actor.id = notifications.actor_id
+	INNER JOIN tasks ON tasks.id = notifications.task_id AND tasks.deleted_at IS NULL
+	INNER JOIN teams ON teams.id = tasks.team_id
+	INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+	`
+}
+
+func (r *NotificationRepository) newNotification() *models.Notification {
+	return &models.Notification{Actor: &models.User{}, Task: &models.TaskRef{}}
+}
+
+func (r *NotificationRepository) scanTargets(notification *models.Notification) []any {
+	return []any{
+		&notification.ID,
+		&notification.CreatedAt,
+		&notification.Kind,
+		&notification.Actor.ID,
+		&notification.Actor.Username,
+		&notification.Actor.Email,
+		&notification.Actor.IsVerified,
+		&notification.TeamName,
+		&notification.Task.ID,
+		&notification.Task.Title,
+		&notification.Task.Status.ID,
+		&notification.Task.Status.Name,
+		&notification.Task.Status.Category,
+		&notification.CommentID,
+		&notification.ReadAt,
+	}
+}
@@ -1,6 +1,25 @@
 package postgres
 
-import "github.com/svetoslaven/tasktracker/internal/pagination"
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+)
+
+type sortExpression struct {
+	expr         string
+	isDescending bool
+}
+
+type paginationClauses struct {
+	totalColumn  string
+	cursorColumn string
+	condition    string
+	orderBy      string
+	limitOffset  string
+}
 
 func CalculateSortDirection(paginationOpts pagination.Options) string {
 	if paginationOpts.IsSortDescending() {
@@ -9,3 +28,119 @@ func CalculateSortDirection(paginationOpts pagination.Options) string {
 		return "ASC"
 	}
 }
+
+func buildPaginationClauses(
+	paginationOpts pagination.Options,
+	fromClause string,
+	sortExprs []sortExpression,
+	args []any,
+) (paginationClauses, []any) {
+	clauses := paginationClauses{
+		totalColumn:  "0",
+		cursorColumn: "NULL::text[]",
+	}
+
+	switch {
+	case !paginationOpts.IncludeTotal():
+	case paginationOpts.IsCursorMode():
+		clauses.totalColumn = "(SELECT count(*) " + fromClause + ")"
+	default:
+		clauses.totalColumn = "count(*) OVER()"
+	}
+
+	cursor, hasCursor := paginationOpts.Cursor()
+
+	if paginationOpts.IsCursorMode() {
+		values := make([]string, 0, len(sortExprs))
+		for _, sortExpr := range sortExprs {
+			values = append(values, sortExpr.expr+"::text")
+		}
+
+		clauses.cursorColumn = "ARRAY[" + strings.Join(values, ", ") + "]"
+	}
+
+	if hasCursor {
+		firstArgIndex := len(args) + 1
+		for _, value := range cursor.Values {
+			args = append(args, value)
+		}
+
+		disjuncts := make([]string, 0, len(sortExprs))
+
+		for i, sortExpr := range sortExprs {
+			conjuncts := make([]string, 0, i+1)
+
+			for j := 0; j < i; j++ {
+				conjuncts = append(conjuncts, fmt.Sprintf("%s = $%d", sortExprs[j].expr, firstArgIndex+j))
+			}
+
+			operator := ">"
+			if sortExpr.isDescending != cursor.IsBackward {
+				operator = "<"
+			}
+
+			conjuncts = append(conjuncts, fmt.Sprintf("%s %s $%d", sortExpr.expr, operator, firstArgIndex+i))
+			disjuncts = append(disjuncts, "("+strings.Join(conjuncts, " AND ")+")")
+		}
+
+		clauses.condition = "AND (" + strings.Join(disjuncts, " OR ") + ")"
+	}
+
+	terms := make([]string, 0, len(sortExprs))
+
+	for _, sortExpr := range sortExprs {
+		direction := "ASC"
+		if sortExpr.isDescending != cursor.IsBackward {
+			direction = "DESC"
+		}
+
+		terms = append(terms, sortExpr.expr+" "+direction)
+	}
+
+	clauses.orderBy = strings.Join(terms, ", ")
+
+	clauses.limitOffset = fmt.Sprintf("LIMIT $%d OFFSET $%d", len(args)+1, len(args)+2)
+	args = append(args, paginationOpts.Limit(), paginationOpts.Offset())
+
+	return clauses, args
+}
+
+func paginateResults[T any](
+	paginationOpts pagination.Options,
+	items []T,
+	cursorValues [][]string,
+	totalRecords int,
+) ([]T, pagination.Metadata) {
+	if !paginationOpts.IsCursorMode() {
+		if !paginationOpts.IncludeTotal() {
+			return items, pagination.Metadata{
+				CurrentPage: paginationOpts.Page(),
+				PageSize:    paginationOpts.PageSize(),
+				FirstPage:   1,
+			}
+		}
+
+		return items, pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	}
+
+	hasMore := len(items) > paginationOpts.PageSize()
+
+	if hasMore {
+		items = items[:paginationOpts.PageSize()]
+		cursorValues = cursorValues[:paginationOpts.PageSize()]
+	}
+
+	if cursor, ok := paginationOpts.Cursor(); ok && cursor.IsBackward {
+		slices.Reverse(items)
+		slices.Reverse(cursorValues)
+	}
+
+	var firstValues, lastValues []string
+
+	if len(cursorValues) > 0 {
+		firstValues = cursorValues[0]
+		lastValues = cursorValues[len(cursorValues)-1]
+	}
+
+	return items, pagination.CalculateCursorMetadata(paginationOpts, firstValues, lastValues, hasMore, totalRecords)
+}
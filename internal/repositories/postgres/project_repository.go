@@ -0,0 +1,215 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type ProjectRepository struct {
+	DB *sql.DB
+}
+
+func (r *ProjectRepository) Insert(ctx context.Context, project *models.Project, teamID int64) error {
+	query := `
+	INSERT INTO projects (name, description, lead_id, team_id)
+	VALUES ($1, $2, $3, $4)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{project.Name, project.Description, r.leadID(project), teamID}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&project.ID, &project.CreatedAt, &project.Version); err != nil {
+		switch {
+		case r.isDuplicateProjectNameError(err):
+			return repositories.ErrDuplicateProjectName
+		default:
+			return err
+		}
+	}
+
+	project.TeamID = teamID
+
+	return nil
+}
+
+func (r *ProjectRepository) GetByID(ctx context.Context, projectID, teamID int64) (*models.Project, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		%s
+		WHERE projects.id = $1 AND projects.team_id = $2
+		`,
+		r.columns(),
+		r.fromClause(),
+	)
+
+	var (
+		project models.Project
+		leadID  *int64
+		lead    nullableUser
+	)
+
+	err := r.DB.QueryRowContext(ctx, query, projectID, teamID).Scan(r.scanTargets(&project, &leadID, &lead)...)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	r.setLead(&project, leadID, lead)
+
+	return &project, nil
+}
+
+func (r *ProjectRepository) GetAll(
+	ctx context.Context,
+	filters models.ProjectFilters,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Project, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), %s
+		%s
+		WHERE projects.team_id = $1 AND projects.is_archived = $2
+		ORDER BY projects.%s %s, projects.id ASC
+		LIMIT $3 OFFSET $4
+		`,
+		r.columns(),
+		r.fromClause(),
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{teamID, filters.IsArchived, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	projects := []*models.Project{}
+
+	for rows.Next() {
+		var (
+			project models.Project
+			leadID  *int64
+			lead    nullableUser
+		)
+
+		if err := rows.Scan(append([]any{&totalRecords}, r.scanTargets(&project, &leadID, &lead)...)...); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		r.setLead(&project, leadID, lead)
+
+		projects = append(projects, &project)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return projects, metadata, nil
+}
+
+func (r *ProjectRepository) Update(ctx context.Context, project *models.Project) error {
+	query := `
+	UPDATE projects
+	SET name = $1, description = $2, lead_id = $3, is_archived = $4, version = version + 1
+	WHERE id = $5 AND version = $6
+	RETURNING version
+	`
+
+	args := []any{
+		project.Name,
+		project.Description,
+		r.leadID(project),
+		project.IsArchived,
+		project.ID,
+		project.Version,
+	}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&project.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		case r.isDuplicateProjectNameError(err):
+			return repositories.ErrDuplicateProjectName
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *ProjectRepository) Delete(ctx context.Context, projectID int64) error {
+	query := `
+	DELETE FROM projects
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, projectID)
+	return err
+}
+
+func (r *ProjectRepository) columns() string {
+	return `
+	projects.id,
+	projects.created_at,
+	projects.name,
+	projects.description,
+	project_lead.id, project_lead.username, project_lead.email, project_lead.is_verified,
+	projects.is_archived,
+	projects.team_id,
+	projects.version
+	`
+}
+
+func (r *ProjectRepository) fromClause() string {
+	return `
+	FROM projects
+	LEFT JOIN users AS project_lead ON project_lead.id = projects.lead_id
+	`
+}
+
+func (r *ProjectRepository) scanTargets(project *models.Project, leadID **int64, lead *nullableUser) []any {
+	return []any{
+		&project.ID,
+		&project.CreatedAt,
+		&project.Name,
+		&project.Description,
+		leadID, &lead.username, &lead.email, &lead.isVerified,
+		&project.IsArchived,
+		&project.TeamID,
+		&project.Version,
+	}
+}
+
+func (r *ProjectRepository) setLead(project *models.Project, leadID *int64, lead nullableUser) {
+	project.Lead = lead.toUser()
+
+	if project.Lead != nil {
+		project.Lead.ID = *leadID
+	}
+}
+
+func (r *ProjectRepository) leadID(project *models.Project) *int64 {
+	if project.Lead == nil {
+		return nil
+	}
+
+	return &project.Lead.ID
+}
+
+func (r *ProjectRepository) isDuplicateProjectNameError(err error) bool {
+	return isDuplicateKeyError(err, "projects_team_id_name_key")
+}
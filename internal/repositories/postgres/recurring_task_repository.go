@@ -0,0 +1,368 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type RecurringTaskRepository struct {
+	DB *sql.DB
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (r *RecurringTaskRepository) Insert(ctx context.Context, recurringTask *models.RecurringTask, teamID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		INSERT INTO recurring_tasks (
+			title, description, priority, labels, rrule, starts_at, is_paused, next_occurrence_at, creator_id, team_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, version
+		`
+
+		args := []any{
+			recurringTask.Title,
+			recurringTask.Description,
+			recurringTask.Priority,
+			pq.Array(recurringTask.Labels),
+			recurringTask.RRule,
+			recurringTask.StartsAt,
+			recurringTask.IsPaused,
+			recurringTask.NextOccurrenceAt,
+			recurringTask.Creator.ID,
+			teamID,
+		}
+
+		err := tx.QueryRowContext(ctx, query, args...).Scan(
+			&recurringTask.ID,
+			&recurringTask.CreatedAt,
+			&recurringTask.Version,
+		)
+		if err != nil {
+			return err
+		}
+
+		recurringTask.TeamID = teamID
+
+		return r.setAssignees(ctx, tx, recurringTask)
+	})
+}
+
+func (r *RecurringTaskRepository) GetByID(ctx context.Context, recurringTaskID, teamID int64) (*models.RecurringTask, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		FROM recurring_tasks
+		INNER JOIN users AS creator ON creator.id = recurring_tasks.creator_id
+		WHERE recurring_tasks.id = $1 AND recurring_tasks.team_id = $2
+		`,
+		r.columns(),
+	)
+
+	recurringTask, err := r.scan(r.DB.QueryRowContext(ctx, query, recurringTaskID, teamID), nil)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return recurringTask, nil
+}
+
+func (r *RecurringTaskRepository) GetAll(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.RecurringTask, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), %s
+		FROM recurring_tasks
+		INNER JOIN users AS creator ON creator.id = recurring_tasks.creator_id
+		WHERE recurring_tasks.team_id = $1
+		ORDER BY recurring_tasks.%s %s NULLS LAST, recurring_tasks.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		r.columns(),
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{teamID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	recurringTasks := []*models.RecurringTask{}
+
+	for rows.Next() {
+		recurringTask, err := r.scan(rows, &totalRecords)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		recurringTasks = append(recurringTasks, recurringTask)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return recurringTasks, metadata, nil
+}
+
+func (r *RecurringTaskRepository) GetAllDue(ctx context.Context, before time.Time, limit int) ([]*models.RecurringTask, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		FROM recurring_tasks
+		INNER JOIN users AS creator ON creator.id = recurring_tasks.creator_id
+		WHERE NOT recurring_tasks.is_paused AND recurring_tasks.next_occurrence_at <= $1
+		ORDER BY recurring_tasks.next_occurrence_at ASC, recurring_tasks.id ASC
+		LIMIT $2
+		`,
+		r.columns(),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, before, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	recurringTasks := []*models.RecurringTask{}
+
+	for rows.Next() {
+		recurringTask, err := r.scan(rows, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		recurringTasks = append(recurringTasks, recurringTask)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return recurringTasks, nil
+}
+
+func (r *RecurringTaskRepository) Update(ctx context.Context, recurringTask *models.RecurringTask) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE recurring_tasks
+		SET
+			title = $1,
+			description = $2,
+			priority = $3,
+			labels = $4,
+			rrule = $5,
+			starts_at = $6,
+			is_paused = $7,
+			next_occurrence_at = $8,
+			version = version + 1
+		WHERE id = $9 AND version = $10
+		RETURNING version
+		`
+
+		args := []any{
+			recurringTask.Title,
+			recurringTask.Description,
+			recurringTask.Priority,
+			pq.Array(recurringTask.Labels),
+			recurringTask.RRule,
+			recurringTask.StartsAt,
+			recurringTask.IsPaused,
+			recurringTask.NextOccurrenceAt,
+			recurringTask.ID,
+			recurringTask.Version,
+		}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&recurringTask.Version); err != nil {
+			return r.handleUpdateError(err)
+		}
+
+		return r.setAssignees(ctx, tx, recurringTask)
+	})
+}
+
+func (r *RecurringTaskRepository) InsertOccurrences(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	tasks []*models.Task,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE recurring_tasks
+		SET next_occurrence_at = $1, generated_count = $2, version = version + 1
+		WHERE id = $3 AND version = $4
+		RETURNING version
+		`
+
+		args := []any{
+			recurringTask.NextOccurrenceAt,
+			recurringTask.GeneratedCount,
+			recurringTask.ID,
+			recurringTask.Version,
+		}
+
+		if err := tx.QueryRowContext(ctx, query, args...).Scan(&recurringTask.Version); err != nil {
+			return r.handleUpdateError(err)
+		}
+
+		for _, task := range tasks {
+			err := insertTask(ctx, tx, task, recurringTask.Creator.ID, recurringTask.TeamID)
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+func (r *RecurringTaskRepository) Delete(ctx context.Context, recurringTaskID int64) error {
+	query := `
+	DELETE FROM recurring_tasks
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, recurringTaskID)
+	return err
+}
+
+func (r *RecurringTaskRepository) setAssignees(ctx context.Context, tx *sql.Tx, recurringTask *models.RecurringTask) error {
+	query := `
+	DELETE FROM recurring_task_assignees
+	WHERE recurring_task_id = $1
+	`
+
+	if _, err := tx.ExecContext(ctx, query, recurringTask.ID); err != nil {
+		return err
+	}
+
+	assigneeIDs := make([]int64, 0, len(recurringTask.Assignees))
+	for _, assignee := range recurringTask.Assignees {
+		assigneeIDs = append(assigneeIDs, assignee.ID)
+	}
+
+	query = `
+	INSERT INTO recurring_task_assignees (recurring_task_id, position, assignee_id)
+	SELECT $1, assignees.position, assignees.id
+	FROM unnest($2::bigint[]) WITH ORDINALITY AS assignees(id, position)
+	`
+
+	_, err := tx.ExecContext(ctx, query, recurringTask.ID, pq.Array(assigneeIDs))
+	return err
+}
+
+func (r *RecurringTaskRepository) handleUpdateError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return repositories.ErrEditConflict
+	default:
+		return err
+	}
+}
+
+func (r *RecurringTaskRepository) columns() string {
+	return `
+	recurring_tasks.id,
+	recurring_tasks.created_at,
+	recurring_tasks.title,
+	recurring_tasks.description,
+	recurring_tasks.priority,
+	recurring_tasks.labels,
+	recurring_tasks.rrule,
+	recurring_tasks.starts_at,
+	recurring_tasks.is_paused,
+	recurring_tasks.next_occurrence_at,
+	recurring_tasks.generated_count,
+	recurring_tasks.team_id,
+	recurring_tasks.version,
+	creator.id, creator.username, creator.email, creator.is_verified,
+	(
+		SELECT coalesce(
+			json_agg(
+				json_build_object(
+					'id', users.id,
+					'username', users.username,
+					'email', users.email,
+					'is_verified', users.is_verified
+				)
+				ORDER BY recurring_task_assignees.position
+			),
+			'[]'
+		)
+		FROM recurring_task_assignees
+		INNER JOIN users ON users.id = recurring_task_assignees.assignee_id
+		WHERE recurring_task_assignees.recurring_task_id = recurring_tasks.id
+	)
+	`
+}
+
+func (r *RecurringTaskRepository) scan(row rowScanner, totalRecords *int) (*models.RecurringTask, error) {
+	var (
+		recurringTask models.RecurringTask
+		labels        pq.StringArray
+		assignees     []byte
+	)
+
+	recurringTask.Creator = &models.User{}
+
+	dest := []any{
+		&recurringTask.ID,
+		&recurringTask.CreatedAt,
+		&recurringTask.Title,
+		&recurringTask.Description,
+		&recurringTask.Priority,
+		&labels,
+		&recurringTask.RRule,
+		&recurringTask.StartsAt,
+		&recurringTask.IsPaused,
+		&recurringTask.NextOccurrenceAt,
+		&recurringTask.GeneratedCount,
+		&recurringTask.TeamID,
+		&recurringTask.Version,
+		&recurringTask.Creator.ID,
+		&recurringTask.Creator.Username,
+		&recurringTask.Creator.Email,
+		&recurringTask.Creator.IsVerified,
+		&assignees,
+	}
+
+	if totalRecords != nil {
+		dest = append([]any{totalRecords}, dest...)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	recurringTask.Labels = labels
+
+	var err error
+
+	recurringTask.Assignees, err = unmarshalUsers(assignees)
+	if err != nil {
+		return nil, err
+	}
+
+	return &recurringTask, nil
+}
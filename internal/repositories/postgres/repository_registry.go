@@ -8,9 +8,21 @@ import (
 
 func NewRepositoryRegistry(db *sql.DB) repositories.RepositoryRegistry {
 	return repositories.RepositoryRegistry{
-		UserRepo:  &UserRepository{DB: db},
-		TokenRepo: &TokenRepository{DB: db},
-		TeamRepo:  &TeamRepository{DB: db},
-		TaskRepo:  &TaskRepository{DB: db},
+		UserRepo:          &UserRepository{DB: db},
+		TokenRepo:         &TokenRepository{DB: db},
+		TeamRepo:          &TeamRepository{DB: db},
+		WorkflowRepo:      &WorkflowRepository{DB: db},
+		TaskRepo:          &TaskRepository{DB: db},
+		CommentRepo:       &CommentRepository{DB: db},
+		AttachmentRepo:    &AttachmentRepository{DB: db},
+		TimeEntryRepo:     &TimeEntryRepository{DB: db},
+		ExtensionRepo:     &DueDateExtensionRepository{DB: db},
+		LabelRepo:         &LabelRepository{DB: db},
+		TaskViewRepo:      &TaskViewRepository{DB: db},
+		RecurringTaskRepo: &RecurringTaskRepository{DB: db},
+		TaskTemplateRepo:  &TaskTemplateRepository{DB: db},
+		NotificationRepo:  &NotificationRepository{DB: db},
+		MilestoneRepo:     &MilestoneRepository{DB: db},
+		ProjectRepo:       &ProjectRepository{DB: db},
 	}
 }
@@ -3,54 +3,99 @@ package postgres
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"html"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/lib/pq"
 	"github.com/svetoslaven/tasktracker/internal/models"
 	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/rank"
 	"github.com/svetoslaven/tasktracker/internal/repositories"
 )
 
+var highlightReplacer = strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>")
+
 type TaskRepository struct {
 	DB *sql.DB
 }
 
-func (r *TaskRepository) Insert(ctx context.Context, task *models.Task, creatorID, assigneeID, teamID int64) error {
-	query := `
-	INSERT INTO tasks (due, title, description, status, priority, creator_id, assignee_id, team_id)
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-	RETURNING id, created_at, version
-	`
+func (r *TaskRepository) Insert(ctx context.Context, task *models.Task, creatorID, teamID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		return insertTask(ctx, tx, task, creatorID, teamID)
+	})
+}
 
-	args := []any{task.Due, task.Title, task.Description, task.Status, task.Priority, creatorID, assigneeID, teamID}
+func (r *TaskRepository) InsertMany(ctx context.Context, tasks []*models.Task, creatorID, teamID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		for _, task := range tasks {
+			if err := insertTask(ctx, tx, task, creatorID, teamID); err != nil {
+				return err
+			}
+		}
 
-	err := r.DB.QueryRowContext(ctx, query, args...).Scan(&task.ID, &task.CreatedAt, &task.Version)
-	return err
+		return nil
+	})
 }
 
-func (r *TaskRepository) GetByID(ctx context.Context, taskID, teamID int64) (*models.Task, error) {
-	query := `
-	SELECT
-		tasks.id,
-		tasks.created_at,
-		tasks.due,
-		tasks.title,
-		tasks.description,
-		tasks.status,
-		tasks.priority,
-		tasks.version,
-		creator.id, creator.username, creator.email, creator.is_verified,
-		assignee.id, assignee.username, assignee.email, assignee.is_verified
-	FROM tasks
-	INNER JOIN users AS creator ON creator.id = tasks.creator_id
-	INNER JOIN users AS assignee ON assignee.id = tasks.assignee_id
-	WHERE tasks.id = $1 AND tasks.team_id = $2
-	`
+func (r *TaskRepository) GetByID(ctx context.Context, taskID, teamID int64, isTrashed bool) (*models.Task, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT
+			tasks.id,
+			tasks.created_at,
+			tasks.due,
+			tasks.title,
+			tasks.description,
+			statuses.id, statuses.name, statuses.category,
+			tasks.rank,
+			tasks.priority,
+			tasks.parent_id,
+			tasks.milestone_id,
+			tasks.project_id,
+			tasks.estimate,
+			tasks.estimate_unit,
+			tasks.is_late,
+			tasks.deleted_at,
+			tasks.team_id,
+			tasks.version,
+			creator.id, creator.username, creator.email, creator.is_verified,
+			progress.completed, progress.total,
+			%s,
+			%s,
+			%s,
+			%s
+		FROM tasks
+		INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+		INNER JOIN users AS creator ON creator.id = tasks.creator_id
+		%s
+		WHERE tasks.id = $1 AND tasks.team_id = $2 AND %s
+		`,
+		r.labelsColumn(),
+		r.assigneesColumn(),
+		r.watchersColumn(),
+		r.mentionsColumn(),
+		r.progressJoin(),
+		r.trashedCondition(isTrashed),
+	)
 
 	var task models.Task
 	task.Creator = &models.User{}
-	task.Assignee = &models.User{}
+
+	var (
+		progress      models.TaskProgress
+		labels        []byte
+		assignees     []byte
+		watchers      []byte
+		mentions      []byte
+		estimateValue *float64
+		estimateUnit  *models.EstimateUnit
+	)
 
 	err := r.DB.QueryRowContext(ctx, query, taskID, teamID).Scan(
 		&task.ID,
@@ -58,103 +103,125 @@ func (r *TaskRepository) GetByID(ctx context.Context, taskID, teamID int64) (*mo
 		&task.Due,
 		&task.Title,
 		&task.Description,
-		&task.Status,
+		&task.Status.ID, &task.Status.Name, &task.Status.Category,
+		&task.Rank,
 		&task.Priority,
+		&task.ParentID,
+		&task.MilestoneID,
+		&task.ProjectID,
+		&estimateValue,
+		&estimateUnit,
+		&task.IsLate,
+		&task.DeletedAt,
+		&task.TeamID,
 		&task.Version,
 		&task.Creator.ID, &task.Creator.Username, &task.Creator.Email, &task.Creator.IsVerified,
-		&task.Assignee.ID, &task.Assignee.Username, &task.Assignee.Email, &task.Assignee.IsVerified,
+		&progress.Completed, &progress.Total,
+		&labels,
+		&assignees,
+		&watchers,
+		&mentions,
 	)
 	if err != nil {
 		return nil, handleQueryRowError(err)
 	}
 
-	return &task, nil
-}
+	if progress.Total > 0 {
+		task.Progress = &progress
+	}
 
-func (r *TaskRepository) GetAll(
-	ctx context.Context,
-	filters models.TaskFilters,
-	teamID int64,
-	paginationOpts pagination.Options,
-) ([]*models.Task, pagination.Metadata, error) {
-	var (
-		filterByCreatedBeforeCondition string
-		filterByCreatedAfterCondition  string
-		filterByDueBeforeCondition     string
-		filterByDueAfterCondition      string
-		filterByStatusCondition        string
-		filterByPriorityCondition      string
-	)
+	task.Estimate = r.newEstimate(estimateValue, estimateUnit)
 
-	args := []any{
-		teamID,
-		filters.CreatorUsername,
-		filters.AssigneeUsername,
-		paginationOpts.Limit(),
-		paginationOpts.Offset(),
+	if err := json.Unmarshal(labels, &task.Labels); err != nil {
+		return nil, err
 	}
 
-	if filters.CreatedBefore != nil {
-		filterByCreatedBeforeCondition = fmt.Sprintf("AND tasks.created_at <= $%d", len(args)+1)
-		args = append(args, *filters.CreatedBefore)
+	if task.Assignees, err = unmarshalUsers(assignees); err != nil {
+		return nil, err
 	}
 
-	if filters.CreatedAfter != nil {
-		filterByCreatedAfterCondition = fmt.Sprintf("AND tasks.created_at >= $%d", len(args)+1)
-		args = append(args, *filters.CreatedAfter)
+	if task.Watchers, err = unmarshalUsers(watchers); err != nil {
+		return nil, err
 	}
 
-	if filters.DueBefore != nil {
-		filterByDueBeforeCondition = fmt.Sprintf("AND tasks.due <= $%d", len(args)+1)
-		args = append(args, *filters.DueBefore)
+	if task.Mentions, err = unmarshalUsers(mentions); err != nil {
+		return nil, err
 	}
 
-	if filters.DueAfter != nil {
-		filterByDueAfterCondition = fmt.Sprintf("AND tasks.due >= $%d", len(args)+1)
-		args = append(args, *filters.DueAfter)
-	}
+	return &task, nil
+}
 
-	if len(filters.Status) > 0 {
-		filterByStatusCondition = fmt.Sprintf("AND tasks.status = ANY($%d)", len(args)+1)
-		args = append(args, pq.Array(filters.Status))
-	}
+func (r *TaskRepository) GetAll(
+	ctx context.Context,
+	filters models.TaskFilters,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Task, pagination.Metadata, error) {
+	fromClause, searchQuery, args := r.filterClauses(filters, teamID)
 
-	if len(filters.Priority) > 0 {
-		filterByPriorityCondition = fmt.Sprintf("AND tasks.priority = ANY($%d)", len(args)+1)
-		args = append(args, pq.Array(filters.Priority))
+	highlightColumns := "NULL, NULL"
+
+	if searchQuery != "" {
+		highlightColumns = fmt.Sprintf(
+			`
+			ts_headline('english', tasks.title, %[1]s, E'StartSel=\x02, StopSel=\x03, HighlightAll=true'),
+			ts_headline('english', tasks.description, %[1]s, E'StartSel=\x02, StopSel=\x03, MaxFragments=2')
+			`,
+			searchQuery,
+		)
 	}
 
+	paginationClauses, args := buildPaginationClauses(
+		paginationOpts,
+		fromClause(""),
+		r.sortExpressions(paginationOpts, searchQuery),
+		args,
+	)
+
 	query := fmt.Sprintf(
 		`
 		SELECT
-			count(*) OVER(),
+			%s,
+			%s,
 			tasks.id,
 			tasks.created_at,
 			tasks.due,
 			tasks.title,
 			tasks.description,
-			tasks.status,
+			statuses.id, statuses.name, statuses.category,
+			tasks.rank,
 			tasks.priority,
+			tasks.parent_id,
+			tasks.milestone_id,
+			tasks.project_id,
+			tasks.estimate,
+			tasks.estimate_unit,
+			tasks.is_late,
+			tasks.deleted_at,
 			tasks.version,
 			creator.username, creator.email, creator.is_verified,
-			assignee.username, assignee.email, assignee.is_verified
-		FROM tasks
-		INNER JOIN users AS creator ON creator.id = tasks.creator_id
-		INNER JOIN users AS assignee ON assignee.id = tasks.assignee_id
-		WHERE tasks.team_id = $1
-			AND creator.username ILIKE '%%' || $2 || '%%'
-			AND assignee.username ILIKE '%%' || $3 || '%%'
-			%s %s
-			%s %s
+			progress.completed, progress.total,
+			%s,
+			%s,
+			%s,
+			%s,
 			%s
+		%s
 			%s
-		ORDER BY tasks.id ASC
-		LIMIT $4 OFFSET $5
+		ORDER BY %s
+		%s
 		`,
-		filterByCreatedBeforeCondition, filterByCreatedAfterCondition,
-		filterByDueBeforeCondition, filterByDueAfterCondition,
-		filterByStatusCondition,
-		filterByPriorityCondition,
+		paginationClauses.totalColumn,
+		paginationClauses.cursorColumn,
+		r.labelsColumn(),
+		r.assigneesColumn(),
+		r.watchersColumn(),
+		r.mentionsColumn(),
+		highlightColumns,
+		fromClause(r.progressJoin()),
+		paginationClauses.condition,
+		paginationClauses.orderBy,
+		paginationClauses.limitOffset,
 	)
 
 	rows, err := r.DB.QueryContext(ctx, query, args...)
@@ -166,58 +233,1291 @@ func (r *TaskRepository) GetAll(
 
 	totalRecords := 0
 	tasks := []*models.Task{}
+	cursorValues := [][]string{}
 
 	for rows.Next() {
 		var task models.Task
 		task.Creator = &models.User{}
-		task.Assignee = &models.User{}
+
+		var (
+			cursorValue          pq.StringArray
+			progress             models.TaskProgress
+			labels               []byte
+			assignees            []byte
+			watchers             []byte
+			mentions             []byte
+			estimateValue        *float64
+			estimateUnit         *models.EstimateUnit
+			titleHighlight       *string
+			descriptionHighlight *string
+		)
 
 		err := rows.Scan(
 			&totalRecords,
+			&cursorValue,
 			&task.ID,
 			&task.CreatedAt,
 			&task.Due,
 			&task.Title,
 			&task.Description,
-			&task.Status,
+			&task.Status.ID, &task.Status.Name, &task.Status.Category,
+			&task.Rank,
 			&task.Priority,
+			&task.ParentID,
+			&task.MilestoneID,
+			&task.ProjectID,
+			&estimateValue,
+			&estimateUnit,
+			&task.IsLate,
+			&task.DeletedAt,
 			&task.Version,
 			&task.Creator.Username, &task.Creator.Email, &task.Creator.IsVerified,
-			&task.Assignee.Username, &task.Assignee.Email, &task.Assignee.IsVerified,
+			&progress.Completed, &progress.Total,
+			&labels,
+			&assignees,
+			&watchers,
+			&mentions,
+			&titleHighlight, &descriptionHighlight,
 		)
 
 		if err != nil {
 			return nil, pagination.Metadata{}, err
 		}
 
+		if progress.Total > 0 {
+			task.Progress = &progress
+		}
+
+		task.Estimate = r.newEstimate(estimateValue, estimateUnit)
+
+		if err := json.Unmarshal(labels, &task.Labels); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if task.Assignees, err = unmarshalUsers(assignees); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if task.Watchers, err = unmarshalUsers(watchers); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if task.Mentions, err = unmarshalUsers(mentions); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if titleHighlight != nil && descriptionHighlight != nil {
+			task.Highlights = &models.TaskHighlights{
+				Title:       r.escapeHighlight(*titleHighlight),
+				Description: r.escapeHighlight(*descriptionHighlight),
+			}
+		}
+
 		tasks = append(tasks, &task)
+		cursorValues = append(cursorValues, cursorValue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, pagination.Metadata{}, err
 	}
 
-	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	tasks, metadata := paginateResults(paginationOpts, tasks, cursorValues, totalRecords)
 	return tasks, metadata, nil
 }
 
-func (r *TaskRepository) UpdateTaskStatus(ctx context.Context, task *models.Task, newStatus models.TaskStatus) error {
-	query := `
-	UPDATE tasks
-	SET status = $1, version = version + 1
-	WHERE id = $2 AND version = $3
-	RETURNING version
-	`
+func (r *TaskRepository) CountByStatus(
+	ctx context.Context,
+	filters models.TaskFilters,
+	teamID int64,
+) (map[string]int, error) {
+	fromClause, _, args := r.filterClauses(filters, teamID)
+
+	query := fmt.Sprintf(
+		`
+		SELECT statuses.name, count(*)
+		%s
+		GROUP BY statuses.name
+		`,
+		fromClause(""),
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	counts := map[string]int{}
+
+	for rows.Next() {
+		var (
+			status string
+			count  int
+		)
+
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
+
+		counts[status] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *TaskRepository) filterClauses(
+	filters models.TaskFilters,
+	teamID int64,
+) (func(extraJoins string) string, string, []any) {
+	var (
+		filterByCreatedBeforeCondition string
+		filterByCreatedAfterCondition  string
+		filterByDueBeforeCondition     string
+		filterByDueAfterCondition      string
+		filterByAssigneeCondition      string
+		filterByWatcherCondition       string
+		filterByStatusCondition        string
+		filterByPriorityCondition      string
+		filterByLabelsCondition        string
+		filterByParentCondition        string
+		filterByMilestoneCondition     string
+		filterByProjectCondition       string
+		filterByEstimateCondition      string
+		filterBySearchCondition        string
+		searchQuery                    string
+	)
+
+	args := []any{teamID, filters.CreatorUsername}
+
+	if filters.AssigneeUsername != "" {
+		filterByAssigneeCondition = r.usernameCondition("task_assignees", "assignee_id", len(args)+1)
+		args = append(args, filters.AssigneeUsername)
+	}
+
+	if filters.WatcherUsername != "" {
+		filterByWatcherCondition = r.usernameCondition("task_watchers", "watcher_id", len(args)+1)
+		args = append(args, filters.WatcherUsername)
+	}
+
+	if filters.CreatedBefore != nil {
+		filterByCreatedBeforeCondition = fmt.Sprintf("AND tasks.created_at <= $%d", len(args)+1)
+		args = append(args, *filters.CreatedBefore)
+	}
+
+	if filters.CreatedAfter != nil {
+		filterByCreatedAfterCondition = fmt.Sprintf("AND tasks.created_at >= $%d", len(args)+1)
+		args = append(args, *filters.CreatedAfter)
+	}
+
+	if filters.DueBefore != nil {
+		filterByDueBeforeCondition = fmt.Sprintf("AND tasks.due <= $%d", len(args)+1)
+		args = append(args, *filters.DueBefore)
+	}
+
+	if filters.DueAfter != nil {
+		filterByDueAfterCondition = fmt.Sprintf("AND tasks.due >= $%d", len(args)+1)
+		args = append(args, *filters.DueAfter)
+	}
+
+	if len(filters.Status) > 0 {
+		filterByStatusCondition = fmt.Sprintf("AND statuses.name = ANY($%d)", len(args)+1)
+		args = append(args, pq.Array(filters.Status))
+	}
+
+	if len(filters.Priority) > 0 {
+		filterByPriorityCondition = fmt.Sprintf("AND tasks.priority = ANY($%d)", len(args)+1)
+		args = append(args, pq.Array(filters.Priority))
+	}
+
+	if len(filters.Labels) > 0 {
+		filterByLabelsCondition = r.labelsCondition(filters.MatchAllLabels, len(args)+1)
+		args = append(args, pq.Array(filters.Labels))
+	}
+
+	if filters.ParentID != nil {
+		filterByParentCondition = fmt.Sprintf("AND tasks.parent_id = $%d", len(args)+1)
+		args = append(args, *filters.ParentID)
+	}
+
+	if filters.MilestoneID != nil {
+		if *filters.MilestoneID == 0 {
+			filterByMilestoneCondition = "AND tasks.milestone_id IS NULL"
+		} else {
+			filterByMilestoneCondition = fmt.Sprintf("AND tasks.milestone_id = $%d", len(args)+1)
+			args = append(args, *filters.MilestoneID)
+		}
+	}
+
+	switch {
+	case filters.ProjectID != nil && *filters.ProjectID == 0:
+		filterByProjectCondition = "AND tasks.project_id IS NULL"
+	case filters.ProjectID != nil:
+		filterByProjectCondition = fmt.Sprintf("AND tasks.project_id = $%d", len(args)+1)
+		args = append(args, *filters.ProjectID)
+	case !filters.IncludeArchived && !filters.IsTrashed && filters.ParentID == nil:
+		filterByProjectCondition = `
+		AND NOT EXISTS (
+			SELECT 1 FROM projects WHERE projects.id = tasks.project_id AND projects.is_archived
+		)
+		`
+	}
+
+	if filters.EstimateUnit != nil {
+		filterByEstimateCondition += fmt.Sprintf(" AND tasks.estimate_unit = $%d", len(args)+1)
+		args = append(args, *filters.EstimateUnit)
+	}
+
+	if filters.MinEstimate != nil {
+		filterByEstimateCondition += fmt.Sprintf(" AND tasks.estimate >= $%d", len(args)+1)
+		args = append(args, *filters.MinEstimate)
+	}
 
-	if err := r.DB.QueryRowContext(ctx, query, newStatus, task.ID, task.Version).Scan(&task.Status); err != nil {
+	if filters.MaxEstimate != nil {
+		filterByEstimateCondition += fmt.Sprintf(" AND tasks.estimate <= $%d", len(args)+1)
+		args = append(args, *filters.MaxEstimate)
+	}
+
+	if filters.HasEstimate != nil {
+		if *filters.HasEstimate {
+			filterByEstimateCondition += " AND tasks.estimate IS NOT NULL"
+		} else {
+			filterByEstimateCondition += " AND tasks.estimate IS NULL"
+		}
+	}
+
+	if filters.Query != "" {
+		searchQuery = fmt.Sprintf("websearch_to_tsquery('english', $%d)", len(args)+1)
+		args = append(args, filters.Query)
+
+		filterBySearchCondition = "AND tasks.search_vector @@ " + searchQuery
+	}
+
+	fromClause := func(extraJoins string) string {
+		return fmt.Sprintf(
+			`
+			FROM tasks
+			INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+			INNER JOIN users AS creator ON creator.id = tasks.creator_id
+			%s
+			WHERE tasks.team_id = $1
+				AND %s
+				AND creator.username ILIKE '%%' || $2 || '%%'
+				%s %s
+				%s %s
+				%s %s
+				%s
+				%s
+				%s
+				%s
+				%s
+				%s
+				%s
+				%s
+			`,
+			extraJoins,
+			r.trashedCondition(filters.IsTrashed),
+			filterByAssigneeCondition, filterByWatcherCondition,
+			filterByCreatedBeforeCondition, filterByCreatedAfterCondition,
+			filterByDueBeforeCondition, filterByDueAfterCondition,
+			filterByStatusCondition,
+			filterByPriorityCondition,
+			filterByLabelsCondition,
+			filterByParentCondition,
+			filterByMilestoneCondition,
+			filterByProjectCondition,
+			filterByEstimateCondition,
+			filterBySearchCondition,
+		)
+	}
+
+	return fromClause, searchQuery, args
+}
+
+func (r *TaskRepository) UpdateTaskStatus(
+	ctx context.Context,
+	task *models.Task,
+	newStatus models.TaskStatus,
+	updaterID int64,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		return r.updateTaskStatus(ctx, tx, task, newStatus, updaterID)
+	})
+}
+
+func (r *TaskRepository) Move(
+	ctx context.Context,
+	task *models.Task,
+	newStatus models.TaskStatus,
+	newRank string,
+	updaterID int64,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		return r.move(ctx, tx, task, newStatus, newRank, updaterID)
+	})
+}
+
+func (r *TaskRepository) GetAdjacentRank(
+	ctx context.Context,
+	anchor *models.Task,
+	isBefore bool,
+	excludedTaskID int64,
+) (string, error) {
+	operator, direction := ">", "ASC"
+	if isBefore {
+		operator, direction = "<", "DESC"
+	}
+
+	query := fmt.Sprintf(
+		`
+		SELECT rank
+		FROM tasks
+		WHERE team_id = $1 AND status_id = $2 AND id <> $3 AND rank %s $4
+		ORDER BY rank %s
+		LIMIT 1
+		`,
+		operator,
+		direction,
+	)
+
+	args := []any{anchor.TeamID, anchor.Status.ID, excludedTaskID, anchor.Rank}
+
+	var adjacentRank string
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&adjacentRank); err != nil {
 		switch {
 		case errors.Is(err, sql.ErrNoRows):
-			return repositories.ErrEditConflict
+			return "", nil
 		default:
-			return err
+			return "", err
 		}
 	}
 
-	return nil
+	return adjacentRank, nil
+}
+
+func (r *TaskRepository) Update(
+	ctx context.Context,
+	task *models.Task,
+	changes []models.TaskChange,
+	updaterID int64,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		return r.update(ctx, tx, task, changes, updaterID)
+	})
+}
+
+func (r *TaskRepository) Trash(ctx context.Context, task *models.Task, removerID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		return r.trash(ctx, tx, task, removerID)
+	})
+}
+
+func (r *TaskRepository) ApplyMutations(
+	ctx context.Context,
+	mutations []*repositories.TaskMutation,
+	actorID int64,
+	isAtomic bool,
+) ([]error, error) {
+	results := make([]error, len(mutations))
+	hasFailedMutation := false
+
+	err := runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		for i, mutation := range mutations {
+			if !isAtomic {
+				if _, err := tx.ExecContext(ctx, "SAVEPOINT task_mutation"); err != nil {
+					return err
+				}
+			}
+
+			err := r.applyMutation(ctx, tx, mutation, actorID)
+
+			switch {
+			case err == nil:
+				if !isAtomic {
+					if _, err := tx.ExecContext(ctx, "RELEASE SAVEPOINT task_mutation"); err != nil {
+						return err
+					}
+				}
+			case isAtomic:
+				results[i] = err
+				hasFailedMutation = true
+
+				return err
+			default:
+				results[i] = err
+
+				if _, err := tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT task_mutation"); err != nil {
+					return err
+				}
+			}
+		}
+
+		return nil
+	})
+	if err != nil && !hasFailedMutation {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *TaskRepository) Restore(ctx context.Context, task *models.Task, restorerID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		UPDATE tasks
+		SET deleted_at = NULL, version = version + 1
+		WHERE id = $1 AND version = $2 AND deleted_at IS NOT NULL
+		RETURNING version
+		`
+
+		if err := tx.QueryRowContext(ctx, query, task.ID, task.Version).Scan(&task.Version); err != nil {
+			return handleTaskUpdateError(err)
+		}
+
+		task.DeletedAt = nil
+
+		return insertTaskEvent(ctx, tx, task.ID, restorerID, models.TaskEventActionRestored, models.TaskChange{})
+	})
+}
+
+func (r *TaskRepository) Purge(ctx context.Context, taskID, teamID int64) error {
+	query := `
+	DELETE FROM tasks
+	WHERE id = $1 AND team_id = $2 AND deleted_at IS NOT NULL
+	`
+
+	err := delete(ctx, r.DB, query, taskID, teamID)
+	return err
+}
 
+func (r *TaskRepository) PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	query := `
+	DELETE FROM tasks
+	WHERE deleted_at IS NOT NULL AND deleted_at <= $1
+	`
+
+	result, err := r.DB.ExecContext(ctx, query, cutoff)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func (r *TaskRepository) GetAllEvents(
+	ctx context.Context,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskEvent, pagination.Metadata, error) {
+	sortDirection := CalculateSortDirection(paginationOpts)
+
+	query := fmt.Sprintf(
+		`
+		SELECT
+			count(*) OVER(),
+			task_events.id,
+			task_events.created_at,
+			task_events.action,
+			task_events.field,
+			task_events.old_value,
+			task_events.new_value,
+			actor.username, actor.email, actor.is_verified
+		FROM task_events
+		LEFT JOIN users AS actor ON actor.id = task_events.actor_id
+		WHERE task_events.task_id = $1
+		ORDER BY task_events.%s %s, task_events.id %s
+		LIMIT $2 OFFSET $3
+		`,
+		paginationOpts.SortColumn(), sortDirection, sortDirection,
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, taskID, paginationOpts.Limit(), paginationOpts.Offset())
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	events := []*models.TaskEvent{}
+
+	for rows.Next() {
+		var (
+			event models.TaskEvent
+			actor nullableUser
+		)
+
+		err := rows.Scan(
+			&totalRecords,
+			&event.ID,
+			&event.CreatedAt,
+			&event.Action,
+			&event.Field,
+			&event.OldValue,
+			&event.NewValue,
+			&actor.username, &actor.email, &actor.isVerified,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		event.Actor = actor.toUser()
+
+		events = append(events, &event)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return events, metadata, nil
+}
+
+func (r *TaskRepository) GetAncestorIDs(ctx context.Context, taskID int64) ([]int64, error) {
+	query := `
+	WITH RECURSIVE ancestors AS (
+		SELECT id, parent_id FROM tasks WHERE id = $1
+		UNION ALL
+		SELECT tasks.id, tasks.parent_id
+		FROM tasks
+		INNER JOIN ancestors ON tasks.id = ancestors.parent_id
+	)
+	SELECT id FROM ancestors
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	ancestorIDs := []int64{}
+
+	for rows.Next() {
+		var ancestorID int64
+
+		if err := rows.Scan(&ancestorID); err != nil {
+			return nil, err
+		}
+
+		ancestorIDs = append(ancestorIDs, ancestorID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return ancestorIDs, nil
+}
+
+func (r *TaskRepository) GetSubtreeHeight(ctx context.Context, taskID int64) (int, error) {
+	query := `
+	WITH RECURSIVE descendants AS (
+		SELECT id, 0 AS depth FROM tasks WHERE id = $1
+		UNION ALL
+		SELECT tasks.id, descendants.depth + 1
+		FROM tasks
+		INNER JOIN descendants ON tasks.parent_id = descendants.id
+	)
+	SELECT max(depth) FROM descendants
+	`
+
+	var height int
+
+	if err := r.DB.QueryRowContext(ctx, query, taskID).Scan(&height); err != nil {
+		return 0, handleQueryRowError(err)
+	}
+
+	return height, nil
+}
+
+func (r *TaskRepository) InsertDependency(ctx context.Context, taskID, blockerID, actorID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		INSERT INTO task_dependencies (task_id, blocker_id)
+		VALUES ($1, $2)
+		`
+
+		if _, err := tx.ExecContext(ctx, query, taskID, blockerID); err != nil {
+			switch {
+			case r.isDuplicateDependencyError(err):
+				return repositories.ErrDependencyExists
+			default:
+				return err
+			}
+		}
+
+		change := models.TaskChange{Field: "blocked_by", NewValue: strconv.FormatInt(blockerID, 10)}
+		return insertTaskEvent(ctx, tx, taskID, actorID, models.TaskEventActionUpdated, change)
+	})
+}
+
+func (r *TaskRepository) DeleteDependency(ctx context.Context, taskID, blockerID, actorID int64) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		query := `
+		DELETE FROM task_dependencies
+		WHERE task_id = $1 AND blocker_id = $2
+		`
+
+		if err := delete(ctx, tx, query, taskID, blockerID); err != nil {
+			return err
+		}
+
+		change := models.TaskChange{Field: "blocked_by", OldValue: strconv.FormatInt(blockerID, 10)}
+		return insertTaskEvent(ctx, tx, taskID, actorID, models.TaskEventActionUpdated, change)
+	})
+}
+
+func (r *TaskRepository) GetDependencies(ctx context.Context, taskID int64) ([]*models.TaskRef, []*models.TaskRef, error) {
+	blockedByQuery := `
+	SELECT tasks.id, tasks.title, statuses.id, statuses.name, statuses.category
+	FROM task_dependencies
+	INNER JOIN tasks ON tasks.id = task_dependencies.blocker_id
+	INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+	WHERE task_dependencies.task_id = $1 AND tasks.deleted_at IS NULL
+	ORDER BY tasks.id ASC
+	`
+
+	blockedBy, err := r.getTaskRefs(ctx, blockedByQuery, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	blocksQuery := `
+	SELECT tasks.id, tasks.title, statuses.id, statuses.name, statuses.category
+	FROM task_dependencies
+	INNER JOIN tasks ON tasks.id = task_dependencies.task_id
+	INNER JOIN workflow_statuses AS statuses ON statuses.id = tasks.status_id
+	WHERE task_dependencies.blocker_id = $1 AND tasks.deleted_at IS NULL
+	ORDER BY tasks.id ASC
+	`
+
+	blocks, err := r.getTaskRefs(ctx, blocksQuery, taskID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return blockedBy, blocks, nil
+}
+
+func (r *TaskRepository) IsTransitivelyBlockedBy(ctx context.Context, taskID, blockerID int64) (bool, error) {
+	query := `
+	WITH RECURSIVE blockers AS (
+		SELECT blocker_id FROM task_dependencies WHERE task_id = $1
+		UNION
+		SELECT task_dependencies.blocker_id
+		FROM task_dependencies
+		INNER JOIN blockers ON task_dependencies.task_id = blockers.blocker_id
+	)
+	SELECT EXISTS (SELECT 1 FROM blockers WHERE blocker_id = $2)
+	`
+
+	var isBlocked bool
+
+	if err := r.DB.QueryRowContext(ctx, query, taskID, blockerID).Scan(&isBlocked); err != nil {
+		return false, err
+	}
+
+	return isBlocked, nil
+}
+
+func (r *TaskRepository) InsertWatcher(ctx context.Context, taskID, watcherID int64) error {
+	query := `
+	INSERT INTO task_watchers (task_id, watcher_id)
+	VALUES ($1, $2)
+	ON CONFLICT DO NOTHING
+	`
+
+	_, err := r.DB.ExecContext(ctx, query, taskID, watcherID)
+	return err
+}
+
+func (r *TaskRepository) DeleteWatcher(ctx context.Context, taskID, watcherID int64) error {
+	query := `
+	DELETE FROM task_watchers
+	WHERE task_id = $1 AND watcher_id = $2
+	`
+
+	_, err := r.DB.ExecContext(ctx, query, taskID, watcherID)
+	return err
+}
+
+func (r *TaskRepository) GetCompletions(
+	ctx context.Context,
+	teamID int64,
+	from, to time.Time,
+) ([]*models.TaskCompletion, error) {
+	query := `
+	SELECT completed_at, estimate, estimate_unit
+	FROM tasks
+	WHERE team_id = $1
+		AND deleted_at IS NULL
+		AND completed_at >= $2
+		AND completed_at < $3
+	ORDER BY completed_at ASC
+	`
+
+	args := []any{teamID, from, to}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	completions := []*models.TaskCompletion{}
+
+	for rows.Next() {
+		var (
+			completion    models.TaskCompletion
+			estimateValue *float64
+			estimateUnit  *models.EstimateUnit
+		)
+
+		if err := rows.Scan(&completion.CompletedAt, &estimateValue, &estimateUnit); err != nil {
+			return nil, err
+		}
+
+		completion.Estimate = r.newEstimate(estimateValue, estimateUnit)
+
+		completions = append(completions, &completion)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return completions, nil
+}
+
+func (r *TaskRepository) getTaskRefs(ctx context.Context, query string, args ...any) ([]*models.TaskRef, error) {
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	refs := []*models.TaskRef{}
+
+	for rows.Next() {
+		var ref models.TaskRef
+
+		if err := rows.Scan(&ref.ID, &ref.Title, &ref.Status.ID, &ref.Status.Name, &ref.Status.Category); err != nil {
+			return nil, err
+		}
+
+		refs = append(refs, &ref)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func (r *TaskRepository) sortExpressions(paginationOpts pagination.Options, searchQuery string) []sortExpression {
+	sortExprs := []sortExpression{}
+
+	for _, field := range paginationOpts.SortFields() {
+		switch field.Column {
+		case "relevance":
+			if searchQuery == "" {
+				continue
+			}
+
+			sortExprs = append(sortExprs, sortExpression{
+				expr:         fmt.Sprintf("ts_rank(tasks.search_vector, %s)", searchQuery),
+				isDescending: !field.IsDescending,
+			})
+		case "estimate":
+			sortExprs = append(sortExprs, sortExpression{
+				expr:         "COALESCE(tasks.estimate, 0)",
+				isDescending: field.IsDescending,
+			})
+		case "status":
+			sortExprs = append(sortExprs, sortExpression{expr: "statuses.position", isDescending: field.IsDescending})
+		default:
+			sortExprs = append(sortExprs, sortExpression{expr: "tasks." + field.Column, isDescending: field.IsDescending})
+		}
+	}
+
+	return append(sortExprs, sortExpression{expr: "tasks.id"})
+}
+
+func (r *TaskRepository) updateTaskStatus(
+	ctx context.Context,
+	tx *sql.Tx,
+	task *models.Task,
+	newStatus models.TaskStatus,
+	updaterID int64,
+) error {
+	newRank, err := lastRank(ctx, tx, task.TeamID, newStatus.ID)
+	if err != nil {
+		return err
+	}
+
+	return r.move(ctx, tx, task, newStatus, newRank, updaterID)
+}
+
+func (r *TaskRepository) move(
+	ctx context.Context,
+	tx *sql.Tx,
+	task *models.Task,
+	newStatus models.TaskStatus,
+	newRank string,
+	updaterID int64,
+) error {
+	query := `
+	UPDATE tasks
+	SET
+		status_id = $1,
+		rank = $2,
+		is_late = $3,
+		completed_at = CASE WHEN $4 THEN coalesce(completed_at, NOW()) END,
+		version = version + 1
+	WHERE id = $5 AND version = $6
+	RETURNING version
+	`
+
+	isCompleted := newStatus.Category == models.StatusCategoryDone
+
+	args := []any{newStatus.ID, newRank, task.IsLate, isCompleted, task.ID, task.Version}
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&task.Version); err != nil {
+		return handleTaskUpdateError(err)
+	}
+
+	task.Rank = newRank
+
+	if task.Status.ID == newStatus.ID {
+		return nil
+	}
+
+	change := models.TaskChange{Field: "status", OldValue: task.Status.Name, NewValue: newStatus.Name}
+
+	task.Status = newStatus
+
+	return insertTaskEvent(ctx, tx, task.ID, updaterID, models.TaskEventActionStatusChanged, change)
+}
+
+func lastRank(ctx context.Context, db dbExecutor, teamID, statusID int64) (string, error) {
+	if _, err := db.ExecContext(ctx, "SELECT 1 FROM workflow_statuses WHERE id = $1 FOR NO KEY UPDATE", statusID); err != nil {
+		return "", err
+	}
+
+	query := `
+	SELECT coalesce(max(rank), '')
+	FROM tasks
+	WHERE team_id = $1 AND status_id = $2
+	`
+
+	var last string
+
+	if err := db.QueryRowContext(ctx, query, teamID, statusID).Scan(&last); err != nil {
+		return "", err
+	}
+
+	return rank.After(last)
+}
+
+func (r *TaskRepository) update(
+	ctx context.Context,
+	tx *sql.Tx,
+	task *models.Task,
+	changes []models.TaskChange,
+	updaterID int64,
+) error {
+	query := `
+	UPDATE tasks
+	SET due = $1, title = $2, description = $3, priority = $4, parent_id = $5, milestone_id = $6,
+		project_id = $7, estimate = $8, estimate_unit = $9, version = version + 1
+	WHERE id = $10 AND version = $11
+	RETURNING version
+	`
+
+	estimateValue, estimateUnit := estimateArgs(task.Estimate)
+
+	args := []any{
+		task.Due,
+		task.Title,
+		task.Description,
+		task.Priority,
+		task.ParentID,
+		task.MilestoneID,
+		task.ProjectID,
+		estimateValue,
+		estimateUnit,
+		task.ID,
+		task.Version,
+	}
+
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&task.Version); err != nil {
+		return handleTaskUpdateError(err)
+	}
+
+	if slices.ContainsFunc(changes, func(change models.TaskChange) bool { return change.Field == "labels" }) {
+		if err := setTaskLabels(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	if slices.ContainsFunc(changes, func(change models.TaskChange) bool { return change.Field == "assignees" }) {
+		if err := setTaskAssignees(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	if slices.ContainsFunc(changes, func(change models.TaskChange) bool { return change.Field == "description" }) {
+		if err := setTaskMentions(ctx, tx, task, updaterID); err != nil {
+			return err
+		}
+	}
+
+	for _, change := range changes {
+		if err := insertTaskEvent(ctx, tx, task.ID, updaterID, models.TaskEventActionUpdated, change); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaskRepository) trash(ctx context.Context, tx *sql.Tx, task *models.Task, removerID int64) error {
+	query := `
+	UPDATE tasks
+	SET deleted_at = NOW(), version = version + 1
+	WHERE id = $1 AND version = $2 AND deleted_at IS NULL
+	RETURNING deleted_at, version
+	`
+
+	err := tx.QueryRowContext(ctx, query, task.ID, task.Version).Scan(&task.DeletedAt, &task.Version)
+	if err != nil {
+		return handleTaskUpdateError(err)
+	}
+
+	return insertTaskEvent(ctx, tx, task.ID, removerID, models.TaskEventActionTrashed, models.TaskChange{})
+}
+
+func (r *TaskRepository) applyMutation(
+	ctx context.Context,
+	tx *sql.Tx,
+	mutation *repositories.TaskMutation,
+	actorID int64,
+) error {
+	switch mutation.Kind {
+	case repositories.TaskMutationStatusChange:
+		return r.updateTaskStatus(ctx, tx, mutation.Task, mutation.NewStatus, actorID)
+	case repositories.TaskMutationUpdate:
+		return r.update(ctx, tx, mutation.Task, mutation.Changes, actorID)
+	case repositories.TaskMutationTrash:
+		return r.trash(ctx, tx, mutation.Task, actorID)
+	default:
+		panic("invalid task mutation kind")
+	}
+}
+
+func insertTask(
+	ctx context.Context,
+	db dbExecutor,
+	task *models.Task,
+	creatorID, teamID int64,
+) error {
+	statusQuery := `
+	SELECT id, name, category
+	FROM workflow_statuses
+	WHERE team_id = $1 AND is_initial = true
+	`
+
+	err := db.QueryRowContext(ctx, statusQuery, teamID).Scan(&task.Status.ID, &task.Status.Name, &task.Status.Category)
+	if err != nil {
+		return err
+	}
+
+	if task.Rank, err = lastRank(ctx, db, teamID, task.Status.ID); err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO tasks (
+		due, title, description, status_id, rank, priority, creator_id, team_id, parent_id, milestone_id,
+		project_id, estimate, estimate_unit, completed_at
+	)
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, CASE WHEN $14 THEN NOW() END)
+	RETURNING id, created_at, version
+	`
+
+	estimateValue, estimateUnit := estimateArgs(task.Estimate)
+
+	args := []any{
+		task.Due,
+		task.Title,
+		task.Description,
+		task.Status.ID,
+		task.Rank,
+		task.Priority,
+		creatorID,
+		teamID,
+		task.ParentID,
+		task.MilestoneID,
+		task.ProjectID,
+		estimateValue,
+		estimateUnit,
+		task.Status.Category == models.StatusCategoryDone,
+	}
+
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&task.ID, &task.CreatedAt, &task.Version); err != nil {
+		return err
+	}
+
+	task.TeamID = teamID
+
+	if err := setTaskLabels(ctx, db, task); err != nil {
+		return err
+	}
+
+	if err := setTaskAssignees(ctx, db, task); err != nil {
+		return err
+	}
+
+	if err := setTaskMentions(ctx, db, task, creatorID); err != nil {
+		return err
+	}
+
+	return insertTaskEvent(ctx, db, task.ID, creatorID, models.TaskEventActionCreated, models.TaskChange{})
+}
+
+func setTaskLabels(ctx context.Context, db dbExecutor, task *models.Task) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM task_labels WHERE task_id = $1", task.ID); err != nil {
+		return err
+	}
+
+	if len(task.Labels) == 0 {
+		return nil
+	}
+
+	labelIDs := make([]int64, 0, len(task.Labels))
+	for _, label := range task.Labels {
+		labelIDs = append(labelIDs, label.ID)
+	}
+
+	query := `
+	INSERT INTO task_labels (task_id, label_id)
+	SELECT $1, unnest($2::bigint[])
+	`
+
+	_, err := db.ExecContext(ctx, query, task.ID, pq.Array(labelIDs))
+	return err
+}
+
+func setTaskAssignees(ctx context.Context, db dbExecutor, task *models.Task) error {
+	if _, err := db.ExecContext(ctx, "DELETE FROM task_assignees WHERE task_id = $1", task.ID); err != nil {
+		return err
+	}
+
+	assigneeIDs := make([]int64, 0, len(task.Assignees))
+	for _, assignee := range task.Assignees {
+		assigneeIDs = append(assigneeIDs, assignee.ID)
+	}
+
+	query := `
+	INSERT INTO task_assignees (task_id, assignee_id)
+	SELECT $1, unnest($2::bigint[])
+	`
+
+	_, err := db.ExecContext(ctx, query, task.ID, pq.Array(assigneeIDs))
+	return err
+}
+
+func setTaskMentions(ctx context.Context, db dbExecutor, task *models.Task, actorID int64) error {
+	return setMentions(ctx, db, "task_mentions", "task_id", task.ID, task.Mentions, actorID, task.ID, nil)
+}
+
+func (r *TaskRepository) labelsColumn() string {
+	return `
+	(
+		SELECT coalesce(
+			json_agg(
+				json_build_object(
+					'id', labels.id,
+					'created_at', labels.created_at,
+					'name', labels.name,
+					'color', labels.color
+				)
+				ORDER BY labels.name
+			),
+			'[]'
+		)
+		FROM task_labels
+		INNER JOIN labels ON labels.id = task_labels.label_id
+		WHERE task_labels.task_id = tasks.id
+	)
+	`
+}
+
+func (r *TaskRepository) assigneesColumn() string {
+	return r.usersColumn("task_assignees", "assignee_id")
+}
+
+func (r *TaskRepository) watchersColumn() string {
+	return r.usersColumn("task_watchers", "watcher_id")
+}
+
+func (r *TaskRepository) mentionsColumn() string {
+	return r.usersColumn("task_mentions", "mentioned_id")
+}
+
+func (r *TaskRepository) usersColumn(table, userColumn string) string {
+	return fmt.Sprintf(
+		`
+		(
+			SELECT coalesce(
+				json_agg(
+					json_build_object(
+						'id', users.id,
+						'username', users.username,
+						'email', users.email,
+						'is_verified', users.is_verified
+					)
+					ORDER BY users.username
+				),
+				'[]'
+			)
+			FROM %[1]s
+			INNER JOIN users ON users.id = %[1]s.%[2]s
+			WHERE %[1]s.task_id = tasks.id
+		)
+		`,
+		table,
+		userColumn,
+	)
+}
+
+func (r *TaskRepository) usernameCondition(table, userColumn string, argIndex int) string {
+	return fmt.Sprintf(
+		`
+		AND EXISTS (
+			SELECT 1
+			FROM %[1]s
+			INNER JOIN users ON users.id = %[1]s.%[2]s
+			WHERE %[1]s.task_id = tasks.id AND users.username ILIKE '%%' || $%[3]d || '%%'
+		)
+		`,
+		table,
+		userColumn,
+		argIndex,
+	)
+}
+
+func (r *TaskRepository) labelsCondition(matchAll bool, argIndex int) string {
+	if matchAll {
+		return fmt.Sprintf(
+			`
+			AND (
+				SELECT count(DISTINCT labels.name)
+				FROM task_labels
+				INNER JOIN labels ON labels.id = task_labels.label_id
+				WHERE task_labels.task_id = tasks.id AND labels.name = ANY($%[1]d)
+			) = cardinality($%[1]d::text[])
+			`,
+			argIndex,
+		)
+	}
+
+	return fmt.Sprintf(
+		`
+		AND EXISTS (
+			SELECT 1
+			FROM task_labels
+			INNER JOIN labels ON labels.id = task_labels.label_id
+			WHERE task_labels.task_id = tasks.id AND labels.name = ANY($%d)
+		)
+		`,
+		argIndex,
+	)
+}
+
+func (r *TaskRepository) progressJoin() string {
+	return fmt.Sprintf(
+		`
+		LEFT JOIN LATERAL (
+			SELECT
+				count(*) FILTER (WHERE child_statuses.category = %d) AS completed,
+				count(*) FILTER (WHERE child_statuses.category != %d) AS total
+			FROM tasks AS children
+			INNER JOIN workflow_statuses AS child_statuses ON child_statuses.id = children.status_id
+			WHERE children.parent_id = tasks.id AND children.deleted_at IS NULL
+		) AS progress ON true
+		`,
+		models.StatusCategoryDone,
+		models.StatusCategoryCancelled,
+	)
+}
+
+func (r *TaskRepository) trashedCondition(isTrashed bool) string {
+	if isTrashed {
+		return "tasks.deleted_at IS NOT NULL"
+	}
+
+	return "tasks.deleted_at IS NULL"
+}
+
+func insertTaskEvent(
+	ctx context.Context,
+	db dbExecutor,
+	taskID, actorID int64,
+	action models.TaskEventAction,
+	change models.TaskChange,
+) error {
+	query := `
+	INSERT INTO task_events (task_id, actor_id, action, field, old_value, new_value)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	`
+
+	args := []any{taskID, actorID, action, change.Field, change.OldValue, change.NewValue}
+
+	_, err := db.ExecContext(ctx, query, args...)
+	return err
+}
+
+func (r *TaskRepository) newEstimate(value *float64, unit *models.EstimateUnit) *models.TaskEstimate {
+	if value == nil || unit == nil {
+		return nil
+	}
+
+	return &models.TaskEstimate{Value: *value, Unit: *unit}
+}
+
+func estimateArgs(estimate *models.TaskEstimate) (any, any) {
+	if estimate == nil {
+		return nil, nil
+	}
+
+	return estimate.Value, estimate.Unit
+}
+
+func (r *TaskRepository) isDuplicateDependencyError(err error) bool {
+	return isDuplicateKeyError(err, "task_dependencies_pkey")
+}
+
+func (r *TaskRepository) escapeHighlight(highlight string) string {
+	return highlightReplacer.Replace(html.EscapeString(highlight))
+}
+
+func handleTaskUpdateError(err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows), isDuplicateKeyError(err, "tasks_team_id_status_id_rank_key"):
+		return repositories.ErrEditConflict
+	default:
+		return err
+	}
 }
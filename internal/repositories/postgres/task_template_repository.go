@@ -0,0 +1,192 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type TaskTemplateRepository struct {
+	DB *sql.DB
+}
+
+func (r *TaskTemplateRepository) Insert(ctx context.Context, template *models.TaskTemplate, teamID int64) error {
+	query := `
+	INSERT INTO task_templates (name, title, description, priority, due_offset, labels, team_id)
+	VALUES ($1, $2, $3, $4, $5, $6, $7)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{
+		template.Name,
+		template.Title,
+		template.Description,
+		template.Priority,
+		template.DueOffset,
+		pq.Array(template.Labels),
+		teamID,
+	}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&template.ID, &template.CreatedAt, &template.Version); err != nil {
+		switch {
+		case r.isDuplicateTemplateNameError(err):
+			return repositories.ErrDuplicateTemplateName
+		default:
+			return err
+		}
+	}
+
+	template.TeamID = teamID
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) GetByID(ctx context.Context, templateID, teamID int64) (*models.TaskTemplate, error) {
+	query := `
+	SELECT id, created_at, name, title, description, priority, due_offset, labels, team_id, version
+	FROM task_templates
+	WHERE id = $1 AND team_id = $2
+	`
+
+	var (
+		template models.TaskTemplate
+		labels   pq.StringArray
+	)
+
+	err := r.DB.QueryRowContext(ctx, query, templateID, teamID).Scan(
+		&template.ID,
+		&template.CreatedAt,
+		&template.Name,
+		&template.Title,
+		&template.Description,
+		&template.Priority,
+		&template.DueOffset,
+		&labels,
+		&template.TeamID,
+		&template.Version,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	template.Labels = labels
+
+	return &template, nil
+}
+
+func (r *TaskTemplateRepository) GetAll(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskTemplate, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), id, created_at, name, title, description, priority, due_offset, labels, team_id, version
+		FROM task_templates
+		WHERE team_id = $1
+		ORDER BY %s %s, id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{teamID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	templates := []*models.TaskTemplate{}
+
+	for rows.Next() {
+		var (
+			template models.TaskTemplate
+			labels   pq.StringArray
+		)
+
+		err := rows.Scan(
+			&totalRecords,
+			&template.ID,
+			&template.CreatedAt,
+			&template.Name,
+			&template.Title,
+			&template.Description,
+			&template.Priority,
+			&template.DueOffset,
+			&labels,
+			&template.TeamID,
+			&template.Version,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		template.Labels = labels
+
+		templates = append(templates, &template)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return templates, metadata, nil
+}
+
+func (r *TaskTemplateRepository) Update(ctx context.Context, template *models.TaskTemplate) error {
+	query := `
+	UPDATE task_templates
+	SET name = $1, title = $2, description = $3, priority = $4, due_offset = $5, labels = $6, version = version + 1
+	WHERE id = $7 AND version = $8
+	RETURNING version
+	`
+
+	args := []any{
+		template.Name,
+		template.Title,
+		template.Description,
+		template.Priority,
+		template.DueOffset,
+		pq.Array(template.Labels),
+		template.ID,
+		template.Version,
+	}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&template.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		case r.isDuplicateTemplateNameError(err):
+			return repositories.ErrDuplicateTemplateName
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaskTemplateRepository) Delete(ctx context.Context, templateID int64) error {
+	query := `
+	DELETE FROM task_templates
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, templateID)
+	return err
+}
+
+func (r *TaskTemplateRepository) isDuplicateTemplateNameError(err error) bool {
+	return isDuplicateKeyError(err, "task_templates_team_id_name_key")
+}
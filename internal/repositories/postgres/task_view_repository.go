@@ -0,0 +1,208 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type TaskViewRepository struct {
+	DB *sql.DB
+}
+
+func (r *TaskViewRepository) Insert(ctx context.Context, view *models.TaskView, teamID int64) error {
+	filters, err := json.Marshal(view.Filters)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	INSERT INTO task_views (name, is_shared, filters, sort, owner_id, team_id)
+	VALUES ($1, $2, $3, $4, $5, $6)
+	RETURNING id, created_at, version
+	`
+
+	args := []any{view.Name, view.IsShared, filters, view.Sort, view.Owner.ID, teamID}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&view.ID, &view.CreatedAt, &view.Version); err != nil {
+		switch {
+		case r.isDuplicateViewNameError(err):
+			return repositories.ErrDuplicateViewName
+		default:
+			return err
+		}
+	}
+
+	view.TeamID = teamID
+
+	return nil
+}
+
+func (r *TaskViewRepository) GetByID(ctx context.Context, viewID, teamID, retrieverID int64) (*models.TaskView, error) {
+	query := `
+	SELECT
+		task_views.id,
+		task_views.created_at,
+		task_views.name,
+		task_views.is_shared,
+		task_views.filters,
+		task_views.sort,
+		task_views.team_id,
+		task_views.version,
+		owner.id, owner.username, owner.email, owner.is_verified
+	FROM task_views
+	INNER JOIN users AS owner ON owner.id = task_views.owner_id
+	WHERE task_views.id = $1 AND task_views.team_id = $2 AND (task_views.is_shared OR task_views.owner_id = $3)
+	`
+
+	var (
+		view    models.TaskView
+		filters []byte
+	)
+
+	view.Owner = &models.User{}
+
+	err := r.DB.QueryRowContext(ctx, query, viewID, teamID, retrieverID).Scan(
+		&view.ID,
+		&view.CreatedAt,
+		&view.Name,
+		&view.IsShared,
+		&filters,
+		&view.Sort,
+		&view.TeamID,
+		&view.Version,
+		&view.Owner.ID, &view.Owner.Username, &view.Owner.Email, &view.Owner.IsVerified,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	if err := json.Unmarshal(filters, &view.Filters); err != nil {
+		return nil, err
+	}
+
+	return &view, nil
+}
+
+func (r *TaskViewRepository) GetAll(
+	ctx context.Context,
+	teamID, retrieverID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskView, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT
+			count(*) OVER(),
+			task_views.id,
+			task_views.created_at,
+			task_views.name,
+			task_views.is_shared,
+			task_views.filters,
+			task_views.sort,
+			owner.username, owner.email, owner.is_verified
+		FROM task_views
+		INNER JOIN users AS owner ON owner.id = task_views.owner_id
+		WHERE task_views.team_id = $1 AND (task_views.is_shared OR task_views.owner_id = $2)
+		ORDER BY task_views.%s %s, task_views.id ASC
+		LIMIT $3 OFFSET $4
+		`,
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{teamID, retrieverID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	views := []*models.TaskView{}
+
+	for rows.Next() {
+		var (
+			view    models.TaskView
+			filters []byte
+		)
+
+		view.Owner = &models.User{}
+
+		err := rows.Scan(
+			&totalRecords,
+			&view.ID,
+			&view.CreatedAt,
+			&view.Name,
+			&view.IsShared,
+			&filters,
+			&view.Sort,
+			&view.Owner.Username, &view.Owner.Email, &view.Owner.IsVerified,
+		)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		if err := json.Unmarshal(filters, &view.Filters); err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		views = append(views, &view)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return views, metadata, nil
+}
+
+func (r *TaskViewRepository) Update(ctx context.Context, view *models.TaskView) error {
+	filters, err := json.Marshal(view.Filters)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE task_views
+	SET name = $1, is_shared = $2, filters = $3, sort = $4, version = version + 1
+	WHERE id = $5 AND version = $6
+	RETURNING version
+	`
+
+	args := []any{view.Name, view.IsShared, filters, view.Sort, view.ID, view.Version}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&view.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		case r.isDuplicateViewNameError(err):
+			return repositories.ErrDuplicateViewName
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TaskViewRepository) Delete(ctx context.Context, viewID int64) error {
+	query := `
+	DELETE FROM task_views
+	WHERE id = $1
+	`
+
+	err := delete(ctx, r.DB, query, viewID)
+	return err
+}
+
+func (r *TaskViewRepository) isDuplicateViewNameError(err error) bool {
+	return isDuplicateKeyError(err, "task_views_team_id_owner_id_name_key")
+}
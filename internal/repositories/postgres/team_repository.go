@@ -16,15 +16,20 @@ type TeamRepository struct {
 	DB *sql.DB
 }
 
-func (r *TeamRepository) InsertTeam(ctx context.Context, team *models.Team, creatorID int64) error {
+func (r *TeamRepository) InsertTeam(
+	ctx context.Context,
+	team *models.Team,
+	workflow *models.Workflow,
+	creatorID int64,
+) error {
 	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
 		query := `
-		INSERT INTO teams (name, is_public)
-		VALUES ($1, $2)
+		INSERT INTO teams (name, is_public, overdue_policy)
+		VALUES ($1, $2, $3)
 		RETURNING id, version
 		`
 
-		args := []any{team.Name, team.IsPublic}
+		args := []any{team.Name, team.IsPublic, team.OverduePolicy}
 
 		if err := tx.QueryRowContext(ctx, query, args...).Scan(&team.ID, &team.Version); err != nil {
 			switch {
@@ -39,13 +44,15 @@ func (r *TeamRepository) InsertTeam(ctx context.Context, team *models.Team, crea
 			return err
 		}
 
-		return nil
+		workflow.TeamID = team.ID
+
+		return replaceWorkflow(ctx, tx, workflow, nil)
 	})
 }
 
 func (r *TeamRepository) GetTeamByName(ctx context.Context, name string, retrieverID int64) (*models.Team, error) {
 	query := `
-	SELECT id, name, is_public, version
+	SELECT id, name, is_public, overdue_policy, version
 	FROM teams
 	WHERE name = $1 AND (is_public = true OR EXISTS(SELECT 1 FROM memberships WHERE team_id = id AND member_id = $2))
 	`
@@ -56,6 +63,30 @@ func (r *TeamRepository) GetTeamByName(ctx context.Context, name string, retriev
 		&team.ID,
 		&team.Name,
 		&team.IsPublic,
+		&team.OverduePolicy,
+		&team.Version,
+	)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return &team, nil
+}
+
+func (r *TeamRepository) GetTeamByID(ctx context.Context, teamID int64) (*models.Team, error) {
+	query := `
+	SELECT id, name, is_public, overdue_policy, version
+	FROM teams
+	WHERE id = $1
+	`
+
+	var team models.Team
+
+	err := r.DB.QueryRowContext(ctx, query, teamID).Scan(
+		&team.ID,
+		&team.Name,
+		&team.IsPublic,
+		&team.OverduePolicy,
 		&team.Version,
 	)
 	if err != nil {
@@ -73,24 +104,40 @@ func (r *TeamRepository) GetAllTeams(
 ) ([]*models.Team, pagination.Metadata, error) {
 	var filterByVisibilityCondition string
 
-	args := []any{retrieverID, filters.Name, paginationOpts.Limit(), paginationOpts.Offset()}
+	args := []any{retrieverID, filters.Name}
 
 	if filters.IsPublic != nil {
 		filterByVisibilityCondition = fmt.Sprintf("AND teams.is_public = $%d", len(args)+1)
 		args = append(args, filters.IsPublic)
 	}
 
-	query := fmt.Sprintf(
+	fromClause := fmt.Sprintf(
 		`
-		SELECT count(*) OVER(), teams.name, teams.is_public
 		FROM teams
 		LEFT JOIN memberships on memberships.team_id = teams.id AND memberships.member_id = $1
 		WHERE teams.name ILIKE '%%' || $2 || '%%' AND (teams.is_public = true OR memberships.member_id IS NOT NULL) %s
-		ORDER BY %s %s, name ASC
-		LIMIT $3 OFFSET $4
 		`,
 		filterByVisibilityCondition,
-		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	sortExprs := []sortExpression{
+		{expr: "teams." + paginationOpts.SortColumn(), isDescending: paginationOpts.IsSortDescending()},
+		{expr: "teams.id"},
+	}
+
+	paginationClauses, args := buildPaginationClauses(paginationOpts, fromClause, sortExprs, args)
+
+	query := fmt.Sprintf(
+		`
+		SELECT %s, %s, teams.name, teams.is_public, teams.overdue_policy
+		%s %s
+		ORDER BY %s
+		%s
+		`,
+		paginationClauses.totalColumn, paginationClauses.cursorColumn,
+		fromClause, paginationClauses.condition,
+		paginationClauses.orderBy,
+		paginationClauses.limitOffset,
 	)
 
 	rows, err := r.DB.QueryContext(ctx, query, args...)
@@ -102,22 +149,27 @@ func (r *TeamRepository) GetAllTeams(
 
 	totalRecords := 0
 	teams := []*models.Team{}
+	cursorValues := [][]string{}
 
 	for rows.Next() {
-		var team models.Team
+		var (
+			team        models.Team
+			cursorValue pq.StringArray
+		)
 
-		if err := rows.Scan(&totalRecords, &team.Name, &team.IsPublic); err != nil {
+		if err := rows.Scan(&totalRecords, &cursorValue, &team.Name, &team.IsPublic, &team.OverduePolicy); err != nil {
 			return nil, pagination.Metadata{}, err
 		}
 
 		teams = append(teams, &team)
+		cursorValues = append(cursorValues, cursorValue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, pagination.Metadata{}, err
 	}
 
-	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	teams, metadata := paginateResults(paginationOpts, teams, cursorValues, totalRecords)
 	return teams, metadata, nil
 }
 
@@ -140,12 +192,12 @@ func (r *TeamRepository) GetMemberRole(ctx context.Context, teamID, memberID int
 func (r *TeamRepository) UpdateTeam(ctx context.Context, team *models.Team) error {
 	query := `
 	UPDATE teams
-	SET name = $1, is_public = $2, version = version + 1
-	WHERE id = $3 AND version = $4
+	SET name = $1, is_public = $2, overdue_policy = $3, version = version + 1
+	WHERE id = $4 AND version = $5
 	RETURNING version
 	`
 
-	args := []any{team.Name, team.IsPublic, team.ID, team.Version}
+	args := []any{team.Name, team.IsPublic, team.OverduePolicy, team.ID, team.Version}
 
 	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&team.Version); err != nil {
 		switch {
@@ -205,26 +257,44 @@ func (r *TeamRepository) GetAllInvitations(
 		}
 	}
 
-	query := fmt.Sprintf(
+	fromClause := fmt.Sprintf(
 		`
-		SELECT
-			count(*) OVER(),
-			invitations.id,
-			teams.name, teams.is_public,
-			inviter.username, inviter.email, inviter.is_verified,
-			invitee.username, invitee.email, invitee.is_verified
 		FROM invitations
 		INNER JOIN teams ON invitations.team_id = teams.id
 		INNER JOIN users AS inviter ON inviter.id = invitations.inviter_id
 		INNER JOIN users AS invitee ON invitee.id = invitations.invitee_id
 		WHERE (inviter.id = $1 OR invitee.id = $1) AND teams.name ILIKE '%%' || $2 || '%%' %s
-		ORDER BY id ASC
-		LIMIT $3 OFFSET $4
 		`,
 		isRetrieverInviterCondition,
 	)
 
-	args := []any{retrieverID, filters.TeamName, paginationOpts.Limit(), paginationOpts.Offset()}
+	args := []any{retrieverID, filters.TeamName}
+
+	paginationClauses, args := buildPaginationClauses(
+		paginationOpts,
+		fromClause,
+		[]sortExpression{{expr: "invitations.id"}},
+		args,
+	)
+
+	query := fmt.Sprintf(
+		`
+		SELECT
+			%s,
+			%s,
+			invitations.id,
+			teams.name, teams.is_public, teams.overdue_policy,
+			inviter.username, inviter.email, inviter.is_verified,
+			invitee.username, invitee.email, invitee.is_verified
+		%s %s
+		ORDER BY %s
+		%s
+		`,
+		paginationClauses.totalColumn, paginationClauses.cursorColumn,
+		fromClause, paginationClauses.condition,
+		paginationClauses.orderBy,
+		paginationClauses.limitOffset,
+	)
 
 	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -235,6 +305,7 @@ func (r *TeamRepository) GetAllInvitations(
 
 	totalRecords := 0
 	invitations := []*models.Invitation{}
+	cursorValues := [][]string{}
 
 	for rows.Next() {
 		var invitation models.Invitation
@@ -242,10 +313,13 @@ func (r *TeamRepository) GetAllInvitations(
 		invitation.Inviter = &models.User{}
 		invitation.Invitee = &models.User{}
 
+		var cursorValue pq.StringArray
+
 		err := rows.Scan(
 			&totalRecords,
+			&cursorValue,
 			&invitation.ID,
-			&invitation.Team.Name, &invitation.Team.IsPublic,
+			&invitation.Team.Name, &invitation.Team.IsPublic, &invitation.Team.OverduePolicy,
 			&invitation.Inviter.Username, &invitation.Inviter.Email, &invitation.Inviter.IsVerified,
 			&invitation.Invitee.Username, &invitation.Invitee.Email, &invitation.Invitee.IsVerified,
 		)
@@ -254,13 +328,14 @@ func (r *TeamRepository) GetAllInvitations(
 		}
 
 		invitations = append(invitations, &invitation)
+		cursorValues = append(cursorValues, cursorValue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, pagination.Metadata{}, err
 	}
 
-	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	invitations, metadata := paginateResults(paginationOpts, invitations, cursorValues, totalRecords)
 	return invitations, metadata, nil
 }
 
@@ -343,28 +418,46 @@ func (r *TeamRepository) GetAllTeamMembers(
 ) ([]*models.Membership, pagination.Metadata, error) {
 	var filterByRoleCondition string
 
-	args := []any{teamID, filters.MemberUsername, paginationOpts.Limit(), paginationOpts.Offset()}
+	args := []any{teamID, filters.MemberUsername}
 
 	if len(filters.MemberRoles) > 0 {
-		filterByRoleCondition = "AND memberships.member_role = ANY($5)"
+		filterByRoleCondition = fmt.Sprintf("AND memberships.member_role = ANY($%d)", len(args)+1)
 		args = append(args, pq.Array(filters.MemberRoles))
 	}
 
-	query := fmt.Sprintf(
+	fromClause := fmt.Sprintf(
 		`
-		SELECT 
-			count(*) OVER(),
-			member.username, member.email, member.is_verified,
-			memberships.member_role
 		FROM memberships
 		INNER JOIN users AS member ON member.id = memberships.member_id
 		WHERE memberships.team_id = $1 AND member.username ILIKE '%%' || $2 || '%%' %s
-		ORDER BY member.username ASC
-		LIMIT $3 OFFSET $4
 		`,
 		filterByRoleCondition,
 	)
 
+	paginationClauses, args := buildPaginationClauses(
+		paginationOpts,
+		fromClause,
+		[]sortExpression{{expr: "member.username"}},
+		args,
+	)
+
+	query := fmt.Sprintf(
+		`
+		SELECT 
+			%s,
+			%s,
+			member.username, member.email, member.is_verified,
+			memberships.member_role
+		%s %s
+		ORDER BY %s
+		%s
+		`,
+		paginationClauses.totalColumn, paginationClauses.cursorColumn,
+		fromClause, paginationClauses.condition,
+		paginationClauses.orderBy,
+		paginationClauses.limitOffset,
+	)
+
 	rows, err := r.DB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, pagination.Metadata{}, err
@@ -374,13 +467,17 @@ func (r *TeamRepository) GetAllTeamMembers(
 
 	totalRecords := 0
 	memberships := []*models.Membership{}
+	cursorValues := [][]string{}
 
 	for rows.Next() {
 		var membership models.Membership
 		membership.Member = &models.User{}
 
+		var cursorValue pq.StringArray
+
 		err := rows.Scan(
 			&totalRecords,
+			&cursorValue,
 			&membership.Member.Username, &membership.Member.Email, &membership.Member.IsVerified,
 			&membership.MemberRole,
 		)
@@ -389,16 +486,56 @@ func (r *TeamRepository) GetAllTeamMembers(
 		}
 
 		memberships = append(memberships, &membership)
+		cursorValues = append(cursorValues, cursorValue)
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, pagination.Metadata{}, err
 	}
 
-	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	memberships, metadata := paginateResults(paginationOpts, memberships, cursorValues, totalRecords)
 	return memberships, metadata, nil
 }
 
+func (r *TeamRepository) GetAllMembersByUsernames(
+	ctx context.Context,
+	usernames []string,
+	teamID int64,
+) ([]*models.User, error) {
+	query := `
+	SELECT users.id, users.username, users.email, users.is_verified
+	FROM memberships
+	INNER JOIN users ON users.id = memberships.member_id
+	WHERE memberships.team_id = $1 AND users.username = ANY($2)
+	ORDER BY users.username ASC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, query, teamID, pq.Array(usernames))
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	members := []*models.User{}
+
+	for rows.Next() {
+		var member models.User
+
+		if err := rows.Scan(&member.ID, &member.Username, &member.Email, &member.IsVerified); err != nil {
+			return nil, err
+		}
+
+		members = append(members, &member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return members, nil
+}
+
 func (r *TeamRepository) UpdateMembership(ctx context.Context, membership *models.Membership) error {
 	query := `
 	UPDATE memberships
@@ -0,0 +1,313 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type TimeEntryRepository struct {
+	DB *sql.DB
+}
+
+func (r *TimeEntryRepository) Insert(ctx context.Context, entry *models.TimeEntry, taskID, userID int64) error {
+	query := `
+	INSERT INTO time_entries (started_at, ended_at, description, task_id, user_id)
+	VALUES ($1, $2, $3, $4, $5)
+	RETURNING id, created_at, EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)::bigint, version
+	`
+
+	args := []any{entry.StartedAt, entry.EndedAt, entry.Description, taskID, userID}
+
+	err := r.DB.QueryRowContext(ctx, query, args...).Scan(
+		&entry.ID,
+		&entry.CreatedAt,
+		&entry.DurationSeconds,
+		&entry.Version,
+	)
+	if err != nil {
+		switch {
+		case isDuplicateKeyError(err, "time_entries_running_user_id_key"):
+			return repositories.ErrTimerAlreadyRunning
+		default:
+			return err
+		}
+	}
+
+	entry.TaskID = taskID
+
+	return nil
+}
+
+func (r *TimeEntryRepository) GetByID(ctx context.Context, entryID, taskID int64) (*models.TimeEntry, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		FROM time_entries
+		INNER JOIN users ON users.id = time_entries.user_id
+		WHERE time_entries.id = $1 AND time_entries.task_id = $2
+		`,
+		r.columns(),
+	)
+
+	entry, err := r.scan(r.DB.QueryRowContext(ctx, query, entryID, taskID), nil)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepository) GetRunning(ctx context.Context, userID int64) (*models.TimeEntry, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT %s
+		FROM time_entries
+		INNER JOIN users ON users.id = time_entries.user_id
+		WHERE time_entries.user_id = $1 AND time_entries.ended_at IS NULL
+		`,
+		r.columns(),
+	)
+
+	entry, err := r.scan(r.DB.QueryRowContext(ctx, query, userID), nil)
+	if err != nil {
+		return nil, handleQueryRowError(err)
+	}
+
+	return entry, nil
+}
+
+func (r *TimeEntryRepository) GetAll(
+	ctx context.Context,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TimeEntry, pagination.Metadata, error) {
+	query := fmt.Sprintf(
+		`
+		SELECT count(*) OVER(), %s
+		FROM time_entries
+		INNER JOIN users ON users.id = time_entries.user_id
+		WHERE time_entries.task_id = $1
+		ORDER BY time_entries.%s %s, time_entries.id ASC
+		LIMIT $2 OFFSET $3
+		`,
+		r.columns(),
+		paginationOpts.SortColumn(), CalculateSortDirection(paginationOpts),
+	)
+
+	args := []any{taskID, paginationOpts.Limit(), paginationOpts.Offset()}
+
+	rows, err := r.DB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	defer rows.Close()
+
+	totalRecords := 0
+	entries := []*models.TimeEntry{}
+
+	for rows.Next() {
+		entry, err := r.scan(rows, &totalRecords)
+		if err != nil {
+			return nil, pagination.Metadata{}, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	metadata := pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), totalRecords)
+	return entries, metadata, nil
+}
+
+func (r *TimeEntryRepository) Update(ctx context.Context, entry *models.TimeEntry) error {
+	query := `
+	UPDATE time_entries
+	SET started_at = $1, ended_at = $2, description = $3, version = version + 1
+	WHERE id = $4 AND version = $5
+	RETURNING EXTRACT(EPOCH FROM COALESCE(ended_at, NOW()) - started_at)::bigint, version
+	`
+
+	args := []any{entry.StartedAt, entry.EndedAt, entry.Description, entry.ID, entry.Version}
+
+	if err := r.DB.QueryRowContext(ctx, query, args...).Scan(&entry.DurationSeconds, &entry.Version); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *TimeEntryRepository) Delete(ctx context.Context, entryID int64) error {
+	query := `
+	DELETE FROM time_entries
+	WHERE id = $1
+	`
+
+	return delete(ctx, r.DB, query, entryID)
+}
+
+func (r *TimeEntryRepository) GetReport(
+	ctx context.Context,
+	teamID int64,
+	from, to, now time.Time,
+	groupBy string,
+) ([]*models.TimeReportRow, error) {
+	var selection string
+
+	switch groupBy {
+	case "member":
+		selection = `
+		SELECT
+			users.username,
+			SUM(EXTRACT(EPOCH FROM entries.ended_at - entries.started_at))::bigint,
+			count(*)
+		FROM entries
+		INNER JOIN users ON users.id = entries.user_id
+		GROUP BY users.username
+		ORDER BY users.username ASC
+		`
+	case "task":
+		selection = `
+		SELECT
+			tasks.id,
+			tasks.title,
+			SUM(EXTRACT(EPOCH FROM entries.ended_at - entries.started_at))::bigint,
+			count(*)
+		FROM entries
+		INNER JOIN tasks ON tasks.id = entries.task_id
+		GROUP BY tasks.id, tasks.title
+		ORDER BY tasks.id ASC
+		`
+	case "day":
+		selection = `
+		SELECT
+			to_char(days.day, 'YYYY-MM-DD'),
+			SUM(EXTRACT(EPOCH FROM LEAST(entries.ended_at, days.day + interval '1 day') - GREATEST(entries.started_at, days.day)))::bigint,
+			count(*)
+		FROM entries
+		CROSS JOIN LATERAL generate_series(
+			date_trunc('day', entries.started_at),
+			entries.ended_at,
+			interval '1 day'
+		) AS days(day)
+		WHERE days.day < entries.ended_at
+		GROUP BY days.day
+		ORDER BY days.day ASC
+		`
+	default:
+		panic("invalid time report grouping")
+	}
+
+	query := fmt.Sprintf(
+		`
+		WITH entries AS (
+			SELECT
+				time_entries.task_id,
+				time_entries.user_id,
+				GREATEST(time_entries.started_at, $2::timestamptz) AT TIME ZONE 'UTC' AS started_at,
+				LEAST(COALESCE(time_entries.ended_at, $4::timestamptz), $3::timestamptz) AT TIME ZONE 'UTC' AS ended_at
+			FROM time_entries
+			INNER JOIN tasks ON tasks.id = time_entries.task_id
+			WHERE tasks.team_id = $1
+				AND tasks.deleted_at IS NULL
+				AND time_entries.started_at < $3
+				AND COALESCE(time_entries.ended_at, $4::timestamptz) > $2
+		)
+		%s
+		`,
+		selection,
+	)
+
+	rows, err := r.DB.QueryContext(ctx, query, teamID, from, to, now)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	reportRows := []*models.TimeReportRow{}
+
+	for rows.Next() {
+		var (
+			row  models.TimeReportRow
+			dest []any
+		)
+
+		switch groupBy {
+		case "member":
+			dest = []any{&row.MemberUsername, &row.TotalSeconds, &row.EntryCount}
+		case "task":
+			dest = []any{&row.TaskID, &row.TaskTitle, &row.TotalSeconds, &row.EntryCount}
+		case "day":
+			dest = []any{&row.Day, &row.TotalSeconds, &row.EntryCount}
+		}
+
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+
+		reportRows = append(reportRows, &row)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return reportRows, nil
+}
+
+func (r *TimeEntryRepository) columns() string {
+	return `
+	time_entries.id,
+	time_entries.created_at,
+	time_entries.started_at,
+	time_entries.ended_at,
+	EXTRACT(EPOCH FROM COALESCE(time_entries.ended_at, NOW()) - time_entries.started_at)::bigint,
+	time_entries.description,
+	time_entries.task_id,
+	time_entries.version,
+	users.id, users.username, users.email, users.is_verified
+	`
+}
+
+func (r *TimeEntryRepository) scan(row rowScanner, totalRecords *int) (*models.TimeEntry, error) {
+	var entry models.TimeEntry
+	entry.User = &models.User{}
+
+	dest := []any{
+		&entry.ID,
+		&entry.CreatedAt,
+		&entry.StartedAt,
+		&entry.EndedAt,
+		&entry.DurationSeconds,
+		&entry.Description,
+		&entry.TaskID,
+		&entry.Version,
+		&entry.User.ID, &entry.User.Username, &entry.User.Email, &entry.User.IsVerified,
+	}
+
+	if totalRecords != nil {
+		dest = append([]any{totalRecords}, dest...)
+	}
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	return &entry, nil
+}
@@ -0,0 +1,299 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/lib/pq"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/rank"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type WorkflowRepository struct {
+	DB *sql.DB
+}
+
+func (r *WorkflowRepository) GetByTeamID(ctx context.Context, teamID int64) (*models.Workflow, error) {
+	statusesQuery := `
+	SELECT
+		workflow_statuses.id,
+		workflow_statuses.name,
+		workflow_statuses.category,
+		workflow_statuses.is_initial,
+		(SELECT count(*) FROM tasks WHERE tasks.status_id = workflow_statuses.id)
+	FROM workflow_statuses
+	WHERE workflow_statuses.team_id = $1
+	ORDER BY workflow_statuses.position ASC, workflow_statuses.id ASC
+	`
+
+	rows, err := r.DB.QueryContext(ctx, statusesQuery, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	workflow := &models.Workflow{
+		Statuses:    []*models.WorkflowStatus{},
+		Transitions: []*models.WorkflowTransition{},
+		TeamID:      teamID,
+	}
+
+	for rows.Next() {
+		var status models.WorkflowStatus
+
+		err := rows.Scan(&status.ID, &status.Name, &status.Category, &status.IsInitial, &status.TaskCount)
+		if err != nil {
+			return nil, err
+		}
+
+		workflow.Statuses = append(workflow.Statuses, &status)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(workflow.Statuses) == 0 {
+		return nil, repositories.ErrNoRecordsFound
+	}
+
+	transitionsQuery := `
+	SELECT from_status.name, to_status.name, workflow_transitions.actors
+	FROM workflow_transitions
+	INNER JOIN workflow_statuses AS from_status ON from_status.id = workflow_transitions.from_status_id
+	INNER JOIN workflow_statuses AS to_status ON to_status.id = workflow_transitions.to_status_id
+	WHERE workflow_transitions.team_id = $1
+	ORDER BY from_status.position ASC, to_status.position ASC
+	`
+
+	rows, err = r.DB.QueryContext(ctx, transitionsQuery, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			transition models.WorkflowTransition
+			actors     pq.Int64Array
+		)
+
+		if err := rows.Scan(&transition.From, &transition.To, &actors); err != nil {
+			return nil, err
+		}
+
+		for _, actor := range actors {
+			transition.Actors = append(transition.Actors, models.TransitionActor(actor))
+		}
+
+		workflow.Transitions = append(workflow.Transitions, &transition)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return workflow, nil
+}
+
+func (r *WorkflowRepository) Replace(
+	ctx context.Context,
+	workflow *models.Workflow,
+	statusMapping map[int64]*models.WorkflowStatus,
+) error {
+	return runInTransaction(ctx, r.DB, nil, func(tx *sql.Tx) error {
+		if _, err := tx.ExecContext(ctx, "SELECT 1 FROM teams WHERE id = $1 FOR UPDATE", workflow.TeamID); err != nil {
+			return err
+		}
+
+		return replaceWorkflow(ctx, tx, workflow, statusMapping)
+	})
+}
+
+func replaceWorkflow(
+	ctx context.Context,
+	db dbExecutor,
+	workflow *models.Workflow,
+	statusMapping map[int64]*models.WorkflowStatus,
+) error {
+	query := `
+	UPDATE workflow_statuses
+	SET is_initial = false
+	WHERE team_id = $1
+	`
+
+	if _, err := db.ExecContext(ctx, query, workflow.TeamID); err != nil {
+		return err
+	}
+
+	statusIDs := make([]int64, 0, len(workflow.Statuses))
+	statusIDsByName := make(map[string]int64, len(workflow.Statuses))
+
+	for i, status := range workflow.Statuses {
+		if err := saveWorkflowStatus(ctx, db, status, i+1, workflow.TeamID); err != nil {
+			return err
+		}
+
+		statusIDs = append(statusIDs, status.ID)
+		statusIDsByName[status.Name] = status.ID
+	}
+
+	for oldStatusID, newStatus := range statusMapping {
+		if err := moveWorkflowStatusTasks(ctx, db, oldStatusID, newStatus, workflow.TeamID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, "DELETE FROM workflow_transitions WHERE team_id = $1", workflow.TeamID); err != nil {
+		return err
+	}
+
+	query = `
+	DELETE FROM workflow_statuses
+	WHERE team_id = $1 AND id != ALL($2)
+	`
+
+	if _, err := db.ExecContext(ctx, query, workflow.TeamID, pq.Array(statusIDs)); err != nil {
+		return err
+	}
+
+	for _, transition := range workflow.Transitions {
+		query := `
+		INSERT INTO workflow_transitions (from_status_id, to_status_id, actors, team_id)
+		VALUES ($1, $2, $3, $4)
+		`
+
+		actors := make([]int64, 0, len(transition.Actors))
+		for _, actor := range transition.Actors {
+			actors = append(actors, int64(actor))
+		}
+
+		args := []any{
+			statusIDsByName[transition.From],
+			statusIDsByName[transition.To],
+			pq.Array(actors),
+			workflow.TeamID,
+		}
+
+		if _, err := db.ExecContext(ctx, query, args...); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func moveWorkflowStatusTasks(
+	ctx context.Context,
+	db dbExecutor,
+	oldStatusID int64,
+	newStatus *models.WorkflowStatus,
+	teamID int64,
+) error {
+	rows, err := db.QueryContext(ctx, "SELECT id FROM tasks WHERE status_id = $1 ORDER BY rank", oldStatusID)
+	if err != nil {
+		return err
+	}
+
+	defer rows.Close()
+
+	var taskIDs []int64
+
+	for rows.Next() {
+		var taskID int64
+
+		if err := rows.Scan(&taskID); err != nil {
+			return err
+		}
+
+		taskIDs = append(taskIDs, taskID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	if len(taskIDs) == 0 {
+		return nil
+	}
+
+	newRank, err := lastRank(ctx, db, teamID, newStatus.ID)
+	if err != nil {
+		return err
+	}
+
+	query := `
+	UPDATE tasks
+	SET
+		status_id = $1,
+		rank = $2,
+		completed_at = CASE WHEN $3 THEN coalesce(completed_at, NOW()) END,
+		version = version + 1
+	WHERE id = $4
+	`
+
+	isCompleted := newStatus.Category == models.StatusCategoryDone
+
+	for _, taskID := range taskIDs {
+		if _, err := db.ExecContext(ctx, query, newStatus.ID, newRank, isCompleted, taskID); err != nil {
+			return err
+		}
+
+		if newRank, err = rank.After(newRank); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func saveWorkflowStatus(
+	ctx context.Context,
+	db dbExecutor,
+	status *models.WorkflowStatus,
+	position int,
+	teamID int64,
+) error {
+	if status.ID == 0 {
+		query := `
+		INSERT INTO workflow_statuses (name, category, position, is_initial, team_id)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+		`
+
+		args := []any{status.Name, status.Category, position, status.IsInitial, teamID}
+
+		return db.QueryRowContext(ctx, query, args...).Scan(&status.ID)
+	}
+
+	query := `
+	UPDATE workflow_statuses
+	SET category = $1, position = $2, is_initial = $3
+	WHERE id = $4 AND team_id = $5
+	RETURNING id
+	`
+
+	args := []any{status.Category, position, status.IsInitial, status.ID, teamID}
+
+	if err := db.QueryRowContext(ctx, query, args...).Scan(&status.ID); err != nil {
+		switch {
+		case errors.Is(err, sql.ErrNoRows):
+			return repositories.ErrEditConflict
+		default:
+			return err
+		}
+	}
+
+	query = `
+	UPDATE tasks
+	SET completed_at = CASE WHEN $1 THEN NOW() END
+	WHERE status_id = $2 AND (completed_at IS NOT NULL) != $1
+	`
+
+	_, err := db.ExecContext(ctx, query, status.Category == models.StatusCategoryDone, status.ID)
+	return err
+}
@@ -3,6 +3,7 @@ package repositories
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/svetoslaven/tasktracker/internal/models"
 	"github.com/svetoslaven/tasktracker/internal/pagination"
@@ -18,7 +19,23 @@ var (
 
 	ErrDuplicateTeamName = errors.New("repositories: duplicate team name")
 
+	ErrDuplicateLabelName = errors.New("repositories: duplicate label name")
+
+	ErrDuplicateMilestoneName = errors.New("repositories: duplicate milestone name")
+
+	ErrDuplicateProjectName = errors.New("repositories: duplicate project name")
+
+	ErrDuplicateViewName = errors.New("repositories: duplicate view name")
+
+	ErrDuplicateTemplateName = errors.New("repositories: duplicate template name")
+
 	ErrInvitationExists = errors.New("repositories: invitation already exists")
+
+	ErrDependencyExists = errors.New("repositories: dependency already exists")
+
+	ErrTimerAlreadyRunning = errors.New("repositories: timer already running")
+
+	ErrPendingExtensionExists = errors.New("repositories: pending extension already exists")
 )
 
 type UserRepository interface {
@@ -35,8 +52,9 @@ type TokenRepository interface {
 }
 
 type TeamRepository interface {
-	InsertTeam(ctx context.Context, team *models.Team, creatorID int64) error
+	InsertTeam(ctx context.Context, team *models.Team, workflow *models.Workflow, creatorID int64) error
 	GetTeamByName(ctx context.Context, name string, retrieverID int64) (*models.Team, error)
+	GetTeamByID(ctx context.Context, teamID int64) (*models.Team, error)
 	GetAllTeams(ctx context.Context, filters models.TeamFilters, paginationOpts pagination.Options, retrieverID int64) ([]*models.Team, pagination.Metadata, error)
 	GetMemberRole(ctx context.Context, teamID, memberID int64) (models.MemberRole, error)
 	UpdateTeam(ctx context.Context, team *models.Team) error
@@ -50,20 +68,165 @@ type TeamRepository interface {
 
 	GetMembership(ctx context.Context, teamID, memberID int64) (*models.Membership, error)
 	GetAllTeamMembers(ctx context.Context, filters models.MembershipFilters, paginationOpts pagination.Options, teamID int64) ([]*models.Membership, pagination.Metadata, error)
+	GetAllMembersByUsernames(ctx context.Context, usernames []string, teamID int64) ([]*models.User, error)
 	UpdateMembership(ctx context.Context, membership *models.Membership) error
 	DeleteMembership(ctx context.Context, teamID, memberID int64) error
 }
 
+type WorkflowRepository interface {
+	GetByTeamID(ctx context.Context, teamID int64) (*models.Workflow, error)
+	Replace(ctx context.Context, workflow *models.Workflow, statusMapping map[int64]*models.WorkflowStatus) error
+}
+
+type TaskMutationKind int
+
+const (
+	TaskMutationStatusChange TaskMutationKind = iota + 1
+	TaskMutationUpdate
+	TaskMutationTrash
+)
+
+type TaskMutation struct {
+	Kind      TaskMutationKind
+	Task      *models.Task
+	NewStatus models.TaskStatus
+	Changes   []models.TaskChange
+}
+
 type TaskRepository interface {
-	Insert(ctx context.Context, task *models.Task, creatorID, assigneeID, teamID int64) error
-	GetByID(ctx context.Context, taskID, teamID int64) (*models.Task, error)
+	Insert(ctx context.Context, task *models.Task, creatorID, teamID int64) error
+	InsertMany(ctx context.Context, tasks []*models.Task, creatorID, teamID int64) error
+	GetByID(ctx context.Context, taskID, teamID int64, isTrashed bool) (*models.Task, error)
 	GetAll(ctx context.Context, filters models.TaskFilters, teamID int64, paginationOpts pagination.Options) ([]*models.Task, pagination.Metadata, error)
-	UpdateTaskStatus(ctx context.Context, task *models.Task, newStatus models.TaskStatus) error
+	CountByStatus(ctx context.Context, filters models.TaskFilters, teamID int64) (map[string]int, error)
+	UpdateTaskStatus(ctx context.Context, task *models.Task, newStatus models.TaskStatus, updaterID int64) error
+	Move(ctx context.Context, task *models.Task, newStatus models.TaskStatus, newRank string, updaterID int64) error
+	GetAdjacentRank(ctx context.Context, anchor *models.Task, isBefore bool, excludedTaskID int64) (string, error)
+	Update(ctx context.Context, task *models.Task, changes []models.TaskChange, updaterID int64) error
+	Trash(ctx context.Context, task *models.Task, removerID int64) error
+	ApplyMutations(ctx context.Context, mutations []*TaskMutation, actorID int64, isAtomic bool) ([]error, error)
+	Restore(ctx context.Context, task *models.Task, restorerID int64) error
+	Purge(ctx context.Context, taskID, teamID int64) error
+	PurgeTrashedBefore(ctx context.Context, cutoff time.Time) (int64, error)
+	GetCompletions(ctx context.Context, teamID int64, from, to time.Time) ([]*models.TaskCompletion, error)
+	GetAllEvents(ctx context.Context, taskID int64, paginationOpts pagination.Options) ([]*models.TaskEvent, pagination.Metadata, error)
+	GetAncestorIDs(ctx context.Context, taskID int64) ([]int64, error)
+	GetSubtreeHeight(ctx context.Context, taskID int64) (int, error)
+	InsertDependency(ctx context.Context, taskID, blockerID, actorID int64) error
+	DeleteDependency(ctx context.Context, taskID, blockerID, actorID int64) error
+	GetDependencies(ctx context.Context, taskID int64) ([]*models.TaskRef, []*models.TaskRef, error)
+	IsTransitivelyBlockedBy(ctx context.Context, taskID, blockerID int64) (bool, error)
+	InsertWatcher(ctx context.Context, taskID, watcherID int64) error
+	DeleteWatcher(ctx context.Context, taskID, watcherID int64) error
+}
+
+type CommentRepository interface {
+	Insert(ctx context.Context, comment *models.Comment, taskID, authorID int64) error
+	GetByID(ctx context.Context, commentID, taskID int64) (*models.Comment, error)
+	GetAll(ctx context.Context, filters models.CommentFilters, taskID int64, paginationOpts pagination.Options) ([]*models.Comment, pagination.Metadata, error)
+	Update(ctx context.Context, comment *models.Comment) error
+	Delete(ctx context.Context, commentID int64) error
+}
+
+type AttachmentRepository interface {
+	Insert(ctx context.Context, attachment *models.Attachment, taskID, uploaderID int64) error
+	GetByID(ctx context.Context, attachmentID, taskID int64) (*models.Attachment, error)
+	GetAll(ctx context.Context, taskID int64, paginationOpts pagination.Options) ([]*models.Attachment, pagination.Metadata, error)
+	Delete(ctx context.Context, attachmentID int64) error
+	GetPendingBlobDeletions(ctx context.Context, limit int) ([]string, error)
+	DeletePendingBlobDeletions(ctx context.Context, storageKeys []string) error
+}
+
+type DueDateExtensionRepository interface {
+	Insert(ctx context.Context, extension *models.DueDateExtension, taskID, requesterID int64) error
+	GetByID(ctx context.Context, extensionID, taskID int64) (*models.DueDateExtension, error)
+	GetAll(ctx context.Context, filters models.DueDateExtensionFilters, taskID int64, paginationOpts pagination.Options) ([]*models.DueDateExtension, pagination.Metadata, error)
+	Decide(ctx context.Context, extension *models.DueDateExtension, task *models.Task, decider *models.User) error
+}
+
+type TimeEntryRepository interface {
+	Insert(ctx context.Context, entry *models.TimeEntry, taskID, userID int64) error
+	GetByID(ctx context.Context, entryID, taskID int64) (*models.TimeEntry, error)
+	GetRunning(ctx context.Context, userID int64) (*models.TimeEntry, error)
+	GetAll(ctx context.Context, taskID int64, paginationOpts pagination.Options) ([]*models.TimeEntry, pagination.Metadata, error)
+	Update(ctx context.Context, entry *models.TimeEntry) error
+	Delete(ctx context.Context, entryID int64) error
+	GetReport(ctx context.Context, teamID int64, from, to, now time.Time, groupBy string) ([]*models.TimeReportRow, error)
+}
+
+type LabelRepository interface {
+	Insert(ctx context.Context, label *models.Label, teamID int64) error
+	GetByID(ctx context.Context, labelID, teamID int64) (*models.Label, error)
+	GetAllByNames(ctx context.Context, names []string, teamID int64) ([]*models.Label, error)
+	GetAll(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.Label, pagination.Metadata, error)
+	Update(ctx context.Context, label *models.Label) error
+	Delete(ctx context.Context, labelID int64) error
+}
+
+type MilestoneRepository interface {
+	Insert(ctx context.Context, milestone *models.Milestone, teamID int64) error
+	GetByID(ctx context.Context, milestoneID, teamID int64) (*models.Milestone, error)
+	GetAll(ctx context.Context, filters models.MilestoneFilters, teamID int64, paginationOpts pagination.Options) ([]*models.Milestone, pagination.Metadata, error)
+	Update(ctx context.Context, milestone *models.Milestone) error
+	Close(ctx context.Context, milestone *models.Milestone, nextMilestoneID *int64, closerID int64) (int, error)
+	Delete(ctx context.Context, milestoneID int64) error
+}
+
+type ProjectRepository interface {
+	Insert(ctx context.Context, project *models.Project, teamID int64) error
+	GetByID(ctx context.Context, projectID, teamID int64) (*models.Project, error)
+	GetAll(ctx context.Context, filters models.ProjectFilters, teamID int64, paginationOpts pagination.Options) ([]*models.Project, pagination.Metadata, error)
+	Update(ctx context.Context, project *models.Project) error
+	Delete(ctx context.Context, projectID int64) error
+}
+
+type TaskViewRepository interface {
+	Insert(ctx context.Context, view *models.TaskView, teamID int64) error
+	GetByID(ctx context.Context, viewID, teamID, retrieverID int64) (*models.TaskView, error)
+	GetAll(ctx context.Context, teamID, retrieverID int64, paginationOpts pagination.Options) ([]*models.TaskView, pagination.Metadata, error)
+	Update(ctx context.Context, view *models.TaskView) error
+	Delete(ctx context.Context, viewID int64) error
+}
+
+type RecurringTaskRepository interface {
+	Insert(ctx context.Context, recurringTask *models.RecurringTask, teamID int64) error
+	GetByID(ctx context.Context, recurringTaskID, teamID int64) (*models.RecurringTask, error)
+	GetAll(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.RecurringTask, pagination.Metadata, error)
+	GetAllDue(ctx context.Context, before time.Time, limit int) ([]*models.RecurringTask, error)
+	Update(ctx context.Context, recurringTask *models.RecurringTask) error
+	InsertOccurrences(ctx context.Context, recurringTask *models.RecurringTask, tasks []*models.Task) error
+	Delete(ctx context.Context, recurringTaskID int64) error
+}
+
+type NotificationRepository interface {
+	GetByID(ctx context.Context, notificationID, recipientID int64) (*models.Notification, error)
+	GetAll(ctx context.Context, filters models.NotificationFilters, recipientID int64, paginationOpts pagination.Options) ([]*models.Notification, pagination.Metadata, error)
+	MarkAsRead(ctx context.Context, notification *models.Notification) error
+}
+
+type TaskTemplateRepository interface {
+	Insert(ctx context.Context, template *models.TaskTemplate, teamID int64) error
+	GetByID(ctx context.Context, templateID, teamID int64) (*models.TaskTemplate, error)
+	GetAll(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.TaskTemplate, pagination.Metadata, error)
+	Update(ctx context.Context, template *models.TaskTemplate) error
+	Delete(ctx context.Context, templateID int64) error
 }
 
 type RepositoryRegistry struct {
-	UserRepo  UserRepository
-	TokenRepo TokenRepository
-	TeamRepo  TeamRepository
-	TaskRepo  TaskRepository
+	UserRepo          UserRepository
+	TokenRepo         TokenRepository
+	TeamRepo          TeamRepository
+	WorkflowRepo      WorkflowRepository
+	TaskRepo          TaskRepository
+	CommentRepo       CommentRepository
+	AttachmentRepo    AttachmentRepository
+	TimeEntryRepo     TimeEntryRepository
+	ExtensionRepo     DueDateExtensionRepository
+	LabelRepo         LabelRepository
+	TaskViewRepo      TaskViewRepository
+	RecurringTaskRepo RecurringTaskRepository
+	TaskTemplateRepo  TaskTemplateRepository
+	NotificationRepo  NotificationRepository
+	MilestoneRepo     MilestoneRepository
+	ProjectRepo       ProjectRepository
 }
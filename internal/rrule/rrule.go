@@ -0,0 +1,352 @@
+package rrule
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Frequency int
+
+const (
+	FrequencyDaily Frequency = iota + 1
+	FrequencyWeekly
+	FrequencyMonthly
+	FrequencyYearly
+)
+
+const maxScannedDays = 100 * 366
+
+var ErrInvalidRule = errors.New("rrule: invalid rule")
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+type Rule struct {
+	frequency  Frequency
+	interval   int
+	count      int
+	until      *time.Time
+	byDay      []time.Weekday
+	byMonthDay []int
+	byMonth    []time.Month
+	weekStart  time.Weekday
+	dtstart    time.Time
+}
+
+func Parse(rule string, dtstart time.Time) (*Rule, error) {
+	r := &Rule{
+		interval:  1,
+		weekStart: time.Monday,
+		dtstart:   dtstart.Truncate(time.Second),
+	}
+
+	rule = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(rule)), "RRULE:")
+	if rule == "" {
+		return nil, fmt.Errorf("%w: rule is empty", ErrInvalidRule)
+	}
+
+	for _, part := range strings.Split(rule, ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("%w: malformed part %q", ErrInvalidRule, part)
+		}
+
+		var err error
+
+		switch key {
+		case "FREQ":
+			r.frequency, err = parseFrequency(value)
+		case "INTERVAL":
+			r.interval, err = parseIntInRange(key, value, 1, 1000)
+		case "COUNT":
+			r.count, err = parseIntInRange(key, value, 1, 10000)
+		case "UNTIL":
+			r.until, err = parseUntil(value, r.dtstart.Location())
+		case "BYDAY":
+			r.byDay, err = parseByDay(value)
+		case "BYMONTHDAY":
+			r.byMonthDay, err = parseByMonthDay(value)
+		case "BYMONTH":
+			r.byMonth, err = parseByMonth(value)
+		case "WKST":
+			r.weekStart, err = parseWeekStart(value)
+		default:
+			err = fmt.Errorf("%w: unsupported part %q", ErrInvalidRule, key)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if r.frequency == 0 {
+		return nil, fmt.Errorf("%w: FREQ is required", ErrInvalidRule)
+	}
+
+	if r.count > 0 && r.until != nil {
+		return nil, fmt.Errorf("%w: COUNT and UNTIL must not be used together", ErrInvalidRule)
+	}
+
+	return r, nil
+}
+
+func (r *Rule) Next(after time.Time, n int) []time.Time {
+	occurrences := make([]time.Time, 0, n)
+
+	if n <= 0 {
+		return occurrences
+	}
+
+	r.iterate(after, func(occurrence time.Time) bool {
+		if occurrence.After(after) {
+			occurrences = append(occurrences, occurrence)
+		}
+
+		return len(occurrences) < n
+	})
+
+	return occurrences
+}
+
+func (r *Rule) Between(after, before time.Time) []time.Time {
+	occurrences := []time.Time{}
+
+	r.iterate(after, func(occurrence time.Time) bool {
+		if occurrence.After(before) {
+			return false
+		}
+
+		if occurrence.After(after) {
+			occurrences = append(occurrences, occurrence)
+		}
+
+		return true
+	})
+
+	return occurrences
+}
+
+func (r *Rule) iterate(from time.Time, fn func(occurrence time.Time) bool) {
+	year, month, day := r.dtstart.Date()
+	hour, minute, second := r.dtstart.Clock()
+
+	start := 0
+	if r.count == 0 {
+		start = max(0, daysBetween(r.dtstart, from.In(r.dtstart.Location())))
+	}
+
+	matched := 0
+
+	for i := start; i < start+maxScannedDays; i++ {
+		candidate := time.Date(year, month, day+i, hour, minute, second, 0, r.dtstart.Location())
+
+		if r.until != nil && candidate.After(*r.until) {
+			return
+		}
+
+		if !r.matches(candidate) {
+			continue
+		}
+
+		matched++
+
+		if !fn(candidate) {
+			return
+		}
+
+		if r.count > 0 && matched >= r.count {
+			return
+		}
+	}
+}
+
+func (r *Rule) matches(candidate time.Time) bool {
+	if !r.isInInterval(candidate) {
+		return false
+	}
+
+	if len(r.byMonth) > 0 && !slices.Contains(r.byMonth, candidate.Month()) {
+		return false
+	}
+
+	if len(r.byMonthDay) > 0 && !r.matchesMonthDay(candidate) {
+		return false
+	}
+
+	if len(r.byDay) > 0 && !slices.Contains(r.byDay, candidate.Weekday()) {
+		return false
+	}
+
+	switch r.frequency {
+	case FrequencyWeekly:
+		if len(r.byDay) == 0 {
+			return candidate.Weekday() == r.dtstart.Weekday()
+		}
+	case FrequencyMonthly:
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 {
+			return candidate.Day() == r.dtstart.Day()
+		}
+	case FrequencyYearly:
+		if len(r.byDay) == 0 && len(r.byMonthDay) == 0 {
+			if len(r.byMonth) == 0 && candidate.Month() != r.dtstart.Month() {
+				return false
+			}
+
+			return candidate.Day() == r.dtstart.Day()
+		}
+	}
+
+	return true
+}
+
+func (r *Rule) isInInterval(candidate time.Time) bool {
+	startYear, startMonth, _ := r.dtstart.Date()
+	year, month, _ := candidate.Date()
+
+	switch r.frequency {
+	case FrequencyDaily:
+		return daysBetween(r.dtstart, candidate)%r.interval == 0
+	case FrequencyWeekly:
+		startOffset := (int(r.dtstart.Weekday()-r.weekStart) + 7) % 7
+		return (daysBetween(r.dtstart, candidate)+startOffset)/7%r.interval == 0
+	case FrequencyMonthly:
+		return ((year-startYear)*12+int(month-startMonth))%r.interval == 0
+	case FrequencyYearly:
+		return (year-startYear)%r.interval == 0
+	default:
+		return false
+	}
+}
+
+func (r *Rule) matchesMonthDay(candidate time.Time) bool {
+	year, month, day := candidate.Date()
+	daysInMonth := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+
+	for _, monthDay := range r.byMonthDay {
+		if monthDay < 0 {
+			monthDay = daysInMonth + monthDay + 1
+		}
+
+		if monthDay == day {
+			return true
+		}
+	}
+
+	return false
+}
+
+func daysBetween(from, to time.Time) int {
+	fromYear, fromMonth, fromDay := from.Date()
+	toYear, toMonth, toDay := to.Date()
+
+	fromDate := time.Date(fromYear, fromMonth, fromDay, 0, 0, 0, 0, time.UTC)
+	toDate := time.Date(toYear, toMonth, toDay, 0, 0, 0, 0, time.UTC)
+
+	return int(toDate.Sub(fromDate).Hours() / 24)
+}
+
+func parseFrequency(value string) (Frequency, error) {
+	switch value {
+	case "DAILY":
+		return FrequencyDaily, nil
+	case "WEEKLY":
+		return FrequencyWeekly, nil
+	case "MONTHLY":
+		return FrequencyMonthly, nil
+	case "YEARLY":
+		return FrequencyYearly, nil
+	default:
+		return 0, fmt.Errorf("%w: unsupported FREQ %q", ErrInvalidRule, value)
+	}
+}
+
+func parseIntInRange(key, value string, min, max int) (int, error) {
+	n, err := strconv.Atoi(value)
+	if err != nil || n < min || n > max {
+		return 0, fmt.Errorf("%w: %s must be between %d and %d", ErrInvalidRule, key, min, max)
+	}
+
+	return n, nil
+}
+
+func parseUntil(value string, location *time.Location) (*time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return &until, nil
+	}
+
+	if until, err := time.ParseInLocation("20060102T150405", value, location); err == nil {
+		return &until, nil
+	}
+
+	if until, err := time.ParseInLocation("20060102", value, location); err == nil {
+		until = until.Add(24*time.Hour - time.Second)
+		return &until, nil
+	}
+
+	return nil, fmt.Errorf("%w: invalid UNTIL %q", ErrInvalidRule, value)
+}
+
+func parseWeekStart(value string) (time.Weekday, error) {
+	day, ok := weekdays[value]
+	if !ok {
+		return 0, fmt.Errorf("%w: unsupported WKST value %q", ErrInvalidRule, value)
+	}
+
+	return day, nil
+}
+
+func parseByDay(value string) ([]time.Weekday, error) {
+	var days []time.Weekday
+
+	for _, s := range strings.Split(value, ",") {
+		day, ok := weekdays[s]
+		if !ok {
+			return nil, fmt.Errorf("%w: unsupported BYDAY value %q", ErrInvalidRule, s)
+		}
+
+		days = append(days, day)
+	}
+
+	return days, nil
+}
+
+func parseByMonthDay(value string) ([]int, error) {
+	var monthDays []int
+
+	for _, s := range strings.Split(value, ",") {
+		monthDay, err := strconv.Atoi(s)
+		if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+			return nil, fmt.Errorf("%w: invalid BYMONTHDAY value %q", ErrInvalidRule, s)
+		}
+
+		monthDays = append(monthDays, monthDay)
+	}
+
+	return monthDays, nil
+}
+
+func parseByMonth(value string) ([]time.Month, error) {
+	var months []time.Month
+
+	for _, s := range strings.Split(value, ",") {
+		month, err := strconv.Atoi(s)
+		if err != nil || month < 1 || month > 12 {
+			return nil, fmt.Errorf("%w: invalid BYMONTH value %q", ErrInvalidRule, s)
+		}
+
+		months = append(months, time.Month(month))
+	}
+
+	return months, nil
+}
@@ -0,0 +1,215 @@
+package rrule
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNext(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("loading location: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		rule    string
+		dtstart time.Time
+		after   time.Time
+		n       int
+		want    []time.Time
+	}{
+		{
+			name:    "weekly interval with monday week start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=MO",
+			dtstart: time.Date(1997, time.August, 5, 9, 0, 0, 0, newYork),
+			after:   time.Date(1997, time.August, 1, 0, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(1997, time.August, 5, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 10, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 19, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 24, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:    "weekly interval with sunday week start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;COUNT=4;BYDAY=TU,SU;WKST=SU",
+			dtstart: time.Date(1997, time.August, 5, 9, 0, 0, 0, newYork),
+			after:   time.Date(1997, time.August, 1, 0, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(1997, time.August, 5, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 17, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 19, 9, 0, 0, 0, newYork),
+				time.Date(1997, time.August, 31, 9, 0, 0, 0, newYork),
+			},
+		},
+		{
+			name:    "weekly interval far from start",
+			rule:    "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO",
+			dtstart: time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC),
+			after:   time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC),
+			n:       2,
+			want: []time.Time{
+				time.Date(2030, time.January, 7, 9, 0, 0, 0, time.UTC),
+				time.Date(2030, time.January, 21, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-1",
+			dtstart: time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
+			after:   time.Date(2024, time.January, 15, 12, 0, 0, 0, time.UTC),
+			n:       4,
+			want: []time.Time{
+				time.Date(2024, time.January, 31, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, time.February, 29, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 31, 12, 0, 0, 0, time.UTC),
+				time.Date(2024, time.April, 30, 12, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "second to last day of month",
+			rule:    "FREQ=MONTHLY;BYMONTHDAY=-2;COUNT=3",
+			dtstart: time.Date(2023, time.February, 1, 8, 0, 0, 0, time.UTC),
+			after:   time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC),
+			n:       10,
+			want: []time.Time{
+				time.Date(2023, time.February, 27, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, time.March, 30, 8, 0, 0, 0, time.UTC),
+				time.Date(2023, time.April, 29, 8, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "count across spring forward",
+			rule:    "FREQ=DAILY;COUNT=4",
+			dtstart: time.Date(2024, time.March, 8, 9, 0, 0, 0, newYork),
+			after:   time.Date(2024, time.March, 1, 0, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(2024, time.March, 8, 14, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 9, 14, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 10, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "count excludes occurrences before after",
+			rule:    "FREQ=DAILY;COUNT=4",
+			dtstart: time.Date(2024, time.March, 8, 9, 0, 0, 0, newYork),
+			after:   time.Date(2024, time.March, 9, 9, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(2024, time.March, 10, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, time.March, 11, 13, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "until across fall back",
+			rule:    "FREQ=WEEKLY;UNTIL=20241110T140000Z",
+			dtstart: time.Date(2024, time.October, 27, 9, 0, 0, 0, newYork),
+			after:   time.Date(2024, time.October, 1, 0, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(2024, time.October, 27, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, time.November, 3, 14, 0, 0, 0, time.UTC),
+				time.Date(2024, time.November, 10, 14, 0, 0, 0, time.UTC),
+			},
+		},
+		{
+			name:    "local until across fall back",
+			rule:    "FREQ=DAILY;UNTIL=20241104T085959",
+			dtstart: time.Date(2024, time.November, 1, 9, 0, 0, 0, newYork),
+			after:   time.Date(2024, time.October, 1, 0, 0, 0, 0, newYork),
+			n:       10,
+			want: []time.Time{
+				time.Date(2024, time.November, 1, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, time.November, 2, 13, 0, 0, 0, time.UTC),
+				time.Date(2024, time.November, 3, 14, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule, err := Parse(tt.rule, tt.dtstart)
+			if err != nil {
+				t.Fatalf("Parse(%q) error = %v", tt.rule, err)
+			}
+
+			got := rule.Next(tt.after, tt.n)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Next() = %v, want %v", got, tt.want)
+			}
+
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Fatalf("Next()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+
+				if got[i].Hour() != tt.dtstart.Hour() {
+					t.Fatalf("Next()[%d] = %v, want wall clock hour %d", i, got[i], tt.dtstart.Hour())
+				}
+			}
+		})
+	}
+}
+
+func TestBetween(t *testing.T) {
+	dtstart := time.Date(2024, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	rule, err := Parse("FREQ=DAILY;INTERVAL=3", dtstart)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	got := rule.Between(
+		time.Date(2030, time.June, 1, 0, 0, 0, 0, time.UTC),
+		time.Date(2030, time.June, 10, 0, 0, 0, 0, time.UTC),
+	)
+
+	want := []time.Time{
+		time.Date(2030, time.June, 1, 10, 0, 0, 0, time.UTC),
+		time.Date(2030, time.June, 4, 10, 0, 0, 0, time.UTC),
+		time.Date(2030, time.June, 7, 10, 0, 0, 0, time.UTC),
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("Between() = %v, want %v", got, want)
+	}
+
+	for i := range got {
+		if !got[i].Equal(want[i]) {
+			t.Fatalf("Between()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestParse(t *testing.T) {
+	dtstart := time.Date(2024, time.January, 1, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		rule string
+		err  error
+	}{
+		{name: "valid", rule: "RRULE:FREQ=WEEKLY;BYDAY=MO,WE;WKST=SU"},
+		{name: "empty", rule: "", err: ErrInvalidRule},
+		{name: "missing frequency", rule: "INTERVAL=2", err: ErrInvalidRule},
+		{name: "count and until", rule: "FREQ=DAILY;COUNT=2;UNTIL=20240201", err: ErrInvalidRule},
+		{name: "invalid week start", rule: "FREQ=WEEKLY;WKST=XX", err: ErrInvalidRule},
+		{name: "zero month day", rule: "FREQ=MONTHLY;BYMONTHDAY=0", err: ErrInvalidRule},
+		{name: "out of range month day", rule: "FREQ=MONTHLY;BYMONTHDAY=-32", err: ErrInvalidRule},
+		{name: "unsupported part", rule: "FREQ=DAILY;BYHOUR=9", err: ErrInvalidRule},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := Parse(tt.rule, dtstart); !errors.Is(err, tt.err) {
+				t.Fatalf("Parse(%q) error = %v, want %v", tt.rule, err, tt.err)
+			}
+		})
+	}
+}
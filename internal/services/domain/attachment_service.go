@@ -0,0 +1,219 @@
+package domain
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/svetoslaven/tasktracker/internal/blobstore"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	attachmentFilenameField = "filename"
+
+	maxAttachmentFilenameLength = 255
+	blobDeletionBatchSize       = 100
+	contentSniffLength          = 512
+)
+
+type AttachmentService struct {
+	AttachmentRepo repositories.AttachmentRepository
+	TeamRepo       repositories.TeamRepository
+	BlobStore      blobstore.BlobStore
+}
+
+func (s *AttachmentService) CreateAttachment(
+	ctx context.Context,
+	upload services.AttachmentUpload,
+	task *models.Task,
+	uploader *models.User,
+) (*models.Attachment, *validator.Validator, error) {
+	validator := validator.New()
+
+	filename := filepath.Base(strings.ReplaceAll(strings.TrimSpace(upload.Filename), `\`, "/"))
+
+	validator.Check(filename != "" && filename != "." && filename != "/", attachmentFilenameField, "Must be provided.")
+	validator.Check(utf8.ValidString(filename), attachmentFilenameField, "Must be valid UTF-8.")
+	validator.CheckStringMaxLength(filename, maxAttachmentFilenameLength, attachmentFilenameField)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canUpload, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, uploader.ID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canUpload {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	head := make([]byte, contentSniffLength)
+
+	n, err := io.ReadFull(upload.Content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, nil, err
+	}
+
+	head = head[:n]
+
+	storageKey, err := s.generateStorageKey()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	size, err := s.BlobStore.Put(ctx, storageKey, io.MultiReader(bytes.NewReader(head), upload.Content))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment := &models.Attachment{
+		Filename:    filename,
+		ContentType: s.detectContentType(head, filename),
+		Size:        size,
+		Uploader:    uploader,
+		StorageKey:  storageKey,
+	}
+
+	if err := s.AttachmentRepo.Insert(ctx, attachment, task.ID, uploader.ID); err != nil {
+		_ = s.BlobStore.Delete(context.Background(), storageKey)
+		return nil, nil, err
+	}
+
+	return attachment, nil, nil
+}
+
+func (s *AttachmentService) GetAttachmentByID(
+	ctx context.Context,
+	attachmentID, taskID int64,
+) (*models.Attachment, error) {
+	attachment, err := s.AttachmentRepo.GetByID(ctx, attachmentID, taskID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return attachment, nil
+}
+
+func (s *AttachmentService) GetAllAttachments(
+	ctx context.Context,
+	paginationOpts pagination.Options,
+	taskID int64,
+) ([]*models.Attachment, pagination.Metadata, error) {
+	return s.AttachmentRepo.GetAll(ctx, taskID, paginationOpts)
+}
+
+func (s *AttachmentService) OpenAttachment(
+	ctx context.Context,
+	attachment *models.Attachment,
+) (io.ReadSeekCloser, error) {
+	content, err := s.BlobStore.Open(ctx, attachment.StorageKey)
+	if err != nil {
+		switch {
+		case errors.Is(err, blobstore.ErrBlobNotFound):
+			return nil, services.ErrNoRecordsFound
+		default:
+			return nil, err
+		}
+	}
+
+	return content, nil
+}
+
+func (s *AttachmentService) DeleteAttachment(
+	ctx context.Context,
+	attachment *models.Attachment,
+	task *models.Task,
+	removerID int64,
+) error {
+	if attachment.Uploader.ID != removerID {
+		canDeleteAttachment, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, removerID, models.MemberRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		if !canDeleteAttachment {
+			return services.ErrNoPermission
+		}
+	}
+
+	if err := s.AttachmentRepo.Delete(ctx, attachment.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	_ = s.deleteBlobs(ctx, []string{attachment.StorageKey})
+
+	return nil
+}
+
+func (s *AttachmentService) DeleteOrphanedBlobs(ctx context.Context) (int, error) {
+	deleted := 0
+
+	for {
+		storageKeys, err := s.AttachmentRepo.GetPendingBlobDeletions(ctx, blobDeletionBatchSize)
+		if err != nil {
+			return deleted, err
+		}
+
+		if len(storageKeys) == 0 {
+			return deleted, nil
+		}
+
+		if err := s.deleteBlobs(ctx, storageKeys); err != nil {
+			return deleted, err
+		}
+
+		deleted += len(storageKeys)
+
+		if len(storageKeys) < blobDeletionBatchSize {
+			return deleted, nil
+		}
+	}
+}
+
+func (s *AttachmentService) deleteBlobs(ctx context.Context, storageKeys []string) error {
+	for _, storageKey := range storageKeys {
+		err := s.BlobStore.Delete(ctx, storageKey)
+		if err != nil && !errors.Is(err, blobstore.ErrInvalidBlobKey) {
+			return err
+		}
+	}
+
+	return s.AttachmentRepo.DeletePendingBlobDeletions(ctx, storageKeys)
+}
+
+func (s *AttachmentService) detectContentType(head []byte, filename string) string {
+	contentType := http.DetectContentType(head)
+
+	if contentType == "application/octet-stream" {
+		if extensionType := mime.TypeByExtension(filepath.Ext(filename)); extensionType != "" {
+			return extensionType
+		}
+	}
+
+	return contentType
+}
+
+func (s *AttachmentService) generateStorageKey() (string, error) {
+	randomBytes := make([]byte, 16)
+
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(randomBytes), nil
+}
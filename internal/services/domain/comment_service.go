@@ -0,0 +1,168 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	commentBodyField     = "body"
+	commentParentIDField = "parent_id"
+)
+
+type CommentService struct {
+	CommentRepo repositories.CommentRepository
+	TeamRepo    repositories.TeamRepository
+}
+
+func (s *CommentService) CreateComment(
+	ctx context.Context,
+	body string,
+	parentID *int64,
+	author *models.User,
+	task *models.Task,
+) (*models.Comment, *validator.Validator, error) {
+	validator := validator.New()
+
+	s.validateBody(body, validator)
+
+	commentMentions, err := resolveMentions(ctx, s.TeamRepo, body, commentBodyField, task.TeamID, validator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canComment, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, author.ID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canComment {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	if parentID != nil {
+		parent, err := s.CommentRepo.GetByID(ctx, *parentID, task.ID)
+		if err != nil {
+			switch {
+			case errors.Is(err, repositories.ErrNoRecordsFound):
+				validator.AddError(commentParentIDField, "Must refer to an existing comment on this task.")
+				return nil, validator, nil
+			default:
+				return nil, nil, err
+			}
+		}
+
+		if parent.ParentID != nil {
+			validator.AddError(commentParentIDField, "Replies can only be made to top-level comments.")
+			return nil, validator, nil
+		}
+	}
+
+	comment := &models.Comment{
+		Body:     body,
+		Author:   author,
+		ParentID: parentID,
+		Mentions: commentMentions,
+	}
+
+	if err := s.CommentRepo.Insert(ctx, comment, task.ID, author.ID); err != nil {
+		return nil, nil, err
+	}
+
+	return comment, nil, nil
+}
+
+func (s *CommentService) GetCommentByID(ctx context.Context, commentID, taskID int64) (*models.Comment, error) {
+	comment, err := s.CommentRepo.GetByID(ctx, commentID, taskID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return comment, nil
+}
+
+func (s *CommentService) GetAllComments(
+	ctx context.Context,
+	filters models.CommentFilters,
+	paginationOpts pagination.Options,
+	taskID int64,
+) ([]*models.Comment, pagination.Metadata, error) {
+	return s.CommentRepo.GetAll(ctx, filters, taskID, paginationOpts)
+}
+
+func (s *CommentService) UpdateComment(
+	ctx context.Context,
+	newBody string,
+	comment *models.Comment,
+	task *models.Task,
+	updaterID int64,
+) (*validator.Validator, error) {
+	if comment.Author.ID != updaterID {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateBody(newBody, validator)
+
+	newMentions, err := resolveMentions(ctx, s.TeamRepo, newBody, commentBodyField, task.TeamID, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if comment.Body == newBody {
+		return nil, nil
+	}
+
+	comment.Body = newBody
+	comment.Mentions = newMentions
+
+	if err := s.CommentRepo.Update(ctx, comment); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
+
+func (s *CommentService) DeleteComment(
+	ctx context.Context,
+	comment *models.Comment,
+	task *models.Task,
+	removerID int64,
+) error {
+	if comment.Author.ID != removerID {
+		canDeleteComment, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, removerID, models.MemberRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		if !canDeleteComment {
+			return services.ErrNoPermission
+		}
+	}
+
+	if err := s.CommentRepo.Delete(ctx, comment.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *CommentService) validateBody(body string, validator *validator.Validator) {
+	validator.CheckNonZero(body, commentBodyField)
+	validator.CheckStringMaxLength(body, 4096, commentBodyField)
+}
@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	extensionProposedDueField = "proposed_due"
+	extensionReasonField      = "reason"
+	extensionNoteField        = "note"
+
+	maxExtensionTextSize = 1000
+)
+
+type DueDateExtensionService struct {
+	ExtensionRepo repositories.DueDateExtensionRepository
+	TeamRepo      repositories.TeamRepository
+}
+
+func (s *DueDateExtensionService) RequestExtension(
+	ctx context.Context,
+	proposedDue time.Time,
+	reason string,
+	task *models.Task,
+	requester *models.User,
+) (*models.DueDateExtension, *validator.Validator, error) {
+	if !isTaskAssignee(task, requester.ID) {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	if task.Status.Category != models.StatusCategoryTodo && task.Status.Category != models.StatusCategoryDoing {
+		return nil, nil, services.ErrTaskStatusConflict
+	}
+
+	validator := validator.New()
+
+	validator.Check(proposedDue.After(task.Due), extensionProposedDueField, "Must be later than the current due date.")
+	validator.Check(
+		proposedDue.After(timefacade.Instance().Now()),
+		extensionProposedDueField,
+		"Must be in the future.",
+	)
+	validator.CheckNonZero(reason, extensionReasonField)
+	validator.CheckStringMaxLength(reason, maxExtensionTextSize, extensionReasonField)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	extension := &models.DueDateExtension{
+		PreviousDue: task.Due,
+		ProposedDue: proposedDue,
+		Reason:      reason,
+		Requester:   requester,
+	}
+
+	if err := s.ExtensionRepo.Insert(ctx, extension, task.ID, requester.ID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrPendingExtensionExists):
+			return nil, nil, services.ErrPendingExtensionExists
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return extension, nil, nil
+}
+
+func (s *DueDateExtensionService) GetExtensionByID(
+	ctx context.Context,
+	extensionID, taskID int64,
+) (*models.DueDateExtension, error) {
+	extension, err := s.ExtensionRepo.GetByID(ctx, extensionID, taskID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return extension, nil
+}
+
+func (s *DueDateExtensionService) GetAllExtensions(
+	ctx context.Context,
+	filters models.DueDateExtensionFilters,
+	paginationOpts pagination.Options,
+	taskID int64,
+) ([]*models.DueDateExtension, pagination.Metadata, error) {
+	return s.ExtensionRepo.GetAll(ctx, filters, taskID, paginationOpts)
+}
+
+func (s *DueDateExtensionService) ApproveExtension(
+	ctx context.Context,
+	note string,
+	extension *models.DueDateExtension,
+	task *models.Task,
+	decider *models.User,
+) (*validator.Validator, error) {
+	return s.decideExtension(ctx, models.ExtensionStatusApproved, note, extension, task, decider)
+}
+
+func (s *DueDateExtensionService) RejectExtension(
+	ctx context.Context,
+	note string,
+	extension *models.DueDateExtension,
+	task *models.Task,
+	decider *models.User,
+) (*validator.Validator, error) {
+	return s.decideExtension(ctx, models.ExtensionStatusRejected, note, extension, task, decider)
+}
+
+func (s *DueDateExtensionService) decideExtension(
+	ctx context.Context,
+	decision models.ExtensionStatus,
+	note string,
+	extension *models.DueDateExtension,
+	task *models.Task,
+	decider *models.User,
+) (*validator.Validator, error) {
+	if task.Creator.ID != decider.ID {
+		canDecide, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, decider.ID, models.MemberRoleLeader)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canDecide {
+			return nil, services.ErrNoPermission
+		}
+	}
+
+	if extension.Status != models.ExtensionStatusPending {
+		return nil, services.ErrExtensionAlreadyDecided
+	}
+
+	validator := validator.New()
+
+	validator.CheckStringMaxLength(note, maxExtensionTextSize, extensionNoteField)
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	extension.Status = decision
+	extension.DecisionNote = note
+
+	if err := s.ExtensionRepo.Decide(ctx, extension, task, decider); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package domain
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type fakeTeamRepo struct {
+	repositories.TeamRepository
+	roles map[int64]models.MemberRole
+}
+
+func (r *fakeTeamRepo) GetMemberRole(ctx context.Context, teamID, memberID int64) (models.MemberRole, error) {
+	role, ok := r.roles[memberID]
+	if !ok {
+		return 0, repositories.ErrNoRecordsFound
+	}
+
+	return role, nil
+}
+
+type fakeTaskRepo struct {
+	repositories.TaskRepository
+	tasks     map[int64]*models.Task
+	updated   []*models.Task
+	mutations []*repositories.TaskMutation
+	blockedBy []*models.TaskRef
+	counts    map[string]int
+}
+
+func (r *fakeTaskRepo) GetAll(
+	ctx context.Context,
+	filters models.TaskFilters,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Task, pagination.Metadata, error) {
+	tasks := []*models.Task{}
+
+	for _, task := range r.tasks {
+		if task.TeamID == teamID && slices.Contains(filters.Status, task.Status.Name) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	slices.SortFunc(tasks, func(a, b *models.Task) int { return cmp.Compare(a.ID, b.ID) })
+
+	offset := min(paginationOpts.Offset(), len(tasks))
+	limit := min(offset+paginationOpts.Limit(), len(tasks))
+
+	return tasks[offset:limit], pagination.Metadata{}, nil
+}
+
+func (r *fakeTaskRepo) CountByStatus(ctx context.Context, filters models.TaskFilters, teamID int64) (map[string]int, error) {
+	return r.counts, nil
+}
+
+func (r *fakeTaskRepo) GetByID(ctx context.Context, taskID, teamID int64, isTrashed bool) (*models.Task, error) {
+	task, ok := r.tasks[taskID]
+	if !ok || task.TeamID != teamID {
+		return nil, repositories.ErrNoRecordsFound
+	}
+
+	return task, nil
+}
+
+func (r *fakeTaskRepo) Update(ctx context.Context, task *models.Task, changes []models.TaskChange, updaterID int64) error {
+	r.updated = append(r.updated, task)
+	return nil
+}
+
+func (r *fakeTaskRepo) ApplyMutations(
+	ctx context.Context,
+	mutations []*repositories.TaskMutation,
+	actorID int64,
+	isAtomic bool,
+) ([]error, error) {
+	r.mutations = append(r.mutations, mutations...)
+	return make([]error, len(mutations)), nil
+}
+
+func (r *fakeTaskRepo) GetDependencies(ctx context.Context, taskID int64) ([]*models.TaskRef, []*models.TaskRef, error) {
+	return r.blockedBy, nil, nil
+}
+
+type fakeRecurringTaskRepo struct {
+	repositories.RecurringTaskRepository
+	due      []*models.RecurringTask
+	inserted []*models.Task
+}
+
+func (r *fakeRecurringTaskRepo) GetAllDue(ctx context.Context, before time.Time, limit int) ([]*models.RecurringTask, error) {
+	return r.due, nil
+}
+
+func (r *fakeRecurringTaskRepo) InsertOccurrences(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	tasks []*models.Task,
+) error {
+	r.inserted = append(r.inserted, tasks...)
+	return nil
+}
+
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+type fakeWorkflowRepo struct {
+	repositories.WorkflowRepository
+	workflow *models.Workflow
+}
+
+func (r *fakeWorkflowRepo) GetByTeamID(ctx context.Context, teamID int64) (*models.Workflow, error) {
+	return r.workflow, nil
+}
@@ -0,0 +1,188 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	labelNameField  = "name"
+	labelColorField = "color"
+)
+
+type LabelService struct {
+	LabelRepo repositories.LabelRepository
+	TeamRepo  repositories.TeamRepository
+}
+
+func (s *LabelService) CreateLabel(
+	ctx context.Context,
+	name, color string,
+	teamID, creatorID int64,
+) (*models.Label, *validator.Validator, error) {
+	canCreateLabel, err := isMemberInRole(ctx, s.TeamRepo, teamID, creatorID, models.MemberRoleAdmin)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateLabel {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateName(name, validator)
+	s.validateColor(color, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	label := &models.Label{
+		Name:  name,
+		Color: strings.ToLower(color),
+	}
+
+	if err := s.LabelRepo.Insert(ctx, label, teamID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateLabelName):
+			s.addNameTakenError(validator)
+			return nil, validator, nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return label, nil, nil
+}
+
+func (s *LabelService) GetLabelByID(ctx context.Context, labelID, teamID int64) (*models.Label, error) {
+	label, err := s.LabelRepo.GetByID(ctx, labelID, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return label, nil
+}
+
+func (s *LabelService) GetAllLabels(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.Label, pagination.Metadata, error) {
+	return s.LabelRepo.GetAll(ctx, teamID, paginationOpts)
+}
+
+func (s *LabelService) UpdateLabel(
+	ctx context.Context,
+	newName, newColor *string,
+	label *models.Label,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateLabel, err := isMemberInRole(ctx, s.TeamRepo, label.TeamID, updaterID, models.MemberRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateLabel {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if newName != nil {
+		s.validateName(*newName, validator)
+	}
+
+	if newColor != nil {
+		s.validateColor(*newColor, validator)
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	var isChanged bool
+
+	if newName != nil && label.Name != *newName {
+		label.Name = *newName
+		isChanged = true
+	}
+
+	if newColor != nil && label.Color != strings.ToLower(*newColor) {
+		label.Color = strings.ToLower(*newColor)
+		isChanged = true
+	}
+
+	if !isChanged {
+		return nil, nil
+	}
+
+	if err := s.LabelRepo.Update(ctx, label); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateLabelName):
+			s.addNameTakenError(validator)
+			return validator, nil
+		default:
+			return nil, handleRepositoryUpdateError(err)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *LabelService) DeleteLabel(ctx context.Context, label *models.Label, removerID int64) error {
+	canDeleteLabel, err := isMemberInRole(ctx, s.TeamRepo, label.TeamID, removerID, models.MemberRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteLabel {
+		return services.ErrNoPermission
+	}
+
+	if err := s.LabelRepo.Delete(ctx, label.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *LabelService) validateName(name string, validator *validator.Validator) {
+	validator.CheckNonZero(name, labelNameField)
+	validator.CheckStringMaxLength(name, 32, labelNameField)
+	validator.Check(!strings.Contains(name, ","), labelNameField, "Must not contain commas.")
+}
+
+func (s *LabelService) validateColor(color string, validator *validator.Validator) {
+	validator.Check(s.isValidColor(color), labelColorField, "Must be a hex color in the #rrggbb format.")
+}
+
+func (s *LabelService) isValidColor(color string) bool {
+	if len(color) != 7 || color[0] != '#' {
+		return false
+	}
+
+	for i := 1; i < len(color); i++ {
+		c := color[i]
+
+		if (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+func (s *LabelService) addNameTakenError(validator *validator.Validator) {
+	validator.AddError(labelNameField, "A label with this name already exists in this team.")
+}
@@ -0,0 +1,56 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func resolveLabels(
+	ctx context.Context,
+	labelRepo repositories.LabelRepository,
+	names []string,
+	teamID int64,
+	validator *validator.Validator,
+) ([]*models.Label, error) {
+	names = uniqueLabelNames(names)
+
+	if len(names) == 0 {
+		return []*models.Label{}, nil
+	}
+
+	labels, err := labelRepo.GetAllByNames(ctx, names, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(labels) != len(names) {
+		for _, name := range names {
+			if !slices.ContainsFunc(labels, func(label *models.Label) bool { return label.Name == name }) {
+				validator.AddError(taskLabelsField, fmt.Sprintf("Contains a label %q that does not exist in this team.", name))
+				break
+			}
+		}
+	}
+
+	return labels, nil
+}
+
+func uniqueLabelNames(names []string) []string {
+	names = slices.Clone(names)
+	slices.Sort(names)
+	return slices.Compact(names)
+}
+
+func labelNames(labels []*models.Label) []string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+
+	return names
+}
@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func isMemberInRole(
+	ctx context.Context,
+	teamRepo repositories.TeamRepository,
+	teamID, memberID int64,
+	role models.MemberRole,
+) (bool, error) {
+	memberRole, err := teamRepo.GetMemberRole(ctx, teamID, memberID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			return false, nil
+		default:
+			return false, err
+		}
+	}
+
+	return memberRole >= role, nil
+}
+
+func isTaskAssignee(task *models.Task, userID int64) bool {
+	return slices.ContainsFunc(task.Assignees, func(assignee *models.User) bool { return assignee.ID == userID })
+}
+
+func validateAssignees(assignees []*models.User, maxAssignees int, field string, validator *validator.Validator) {
+	validator.Check(len(assignees) > 0, field, "Must contain at least one assignee.")
+	validator.Check(
+		len(assignees) <= maxAssignees,
+		field,
+		fmt.Sprintf("Must not contain more than %d assignees.", maxAssignees),
+	)
+
+	ids := make([]int64, 0, len(assignees))
+	for _, assignee := range assignees {
+		ids = append(ids, assignee.ID)
+	}
+
+	slices.Sort(ids)
+	validator.Check(len(slices.Compact(ids)) == len(assignees), field, "Must not contain duplicates.")
+}
@@ -0,0 +1,42 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/svetoslaven/tasktracker/internal/mentions"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+func resolveMentions(
+	ctx context.Context,
+	teamRepo repositories.TeamRepository,
+	text, field string,
+	teamID int64,
+	validator *validator.Validator,
+) ([]*models.User, error) {
+	usernames := mentions.Parse(text)
+
+	if len(usernames) == 0 {
+		return []*models.User{}, nil
+	}
+
+	members, err := teamRepo.GetAllMembersByUsernames(ctx, usernames, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) != len(usernames) {
+		for _, username := range usernames {
+			if !slices.ContainsFunc(members, func(member *models.User) bool { return member.Username == username }) {
+				validator.AddError(field, fmt.Sprintf("Mentions %q, who is not a member of this team.", username))
+				break
+			}
+		}
+	}
+
+	return members, nil
+}
@@ -0,0 +1,283 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	milestoneNameField            = "name"
+	milestoneStartsAtField        = "starts_at"
+	milestoneEndsAtField          = "ends_at"
+	milestoneStatesField          = "states"
+	milestoneNextMilestoneIDField = "next_milestone_id"
+
+	maxMilestoneNameLength = 64
+)
+
+type MilestoneService struct {
+	MilestoneRepo repositories.MilestoneRepository
+	TeamRepo      repositories.TeamRepository
+}
+
+func (s *MilestoneService) CreateMilestone(
+	ctx context.Context,
+	name string,
+	startsAt, endsAt time.Time,
+	teamID, creatorID int64,
+) (*models.Milestone, *validator.Validator, error) {
+	canCreateMilestone, err := isMemberInRole(ctx, s.TeamRepo, teamID, creatorID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateMilestone {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateName(name, validator)
+	s.validateDates(startsAt, endsAt, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	milestone := &models.Milestone{
+		Name:     name,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		State:    models.MilestoneStateOpen,
+	}
+
+	if err := s.MilestoneRepo.Insert(ctx, milestone, teamID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateMilestoneName):
+			s.addNameTakenError(validator)
+			return nil, validator, nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return milestone, nil, nil
+}
+
+func (s *MilestoneService) GetMilestoneByID(ctx context.Context, milestoneID, teamID int64) (*models.Milestone, error) {
+	milestone, err := s.MilestoneRepo.GetByID(ctx, milestoneID, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return milestone, nil
+}
+
+func (s *MilestoneService) GetAllMilestones(
+	ctx context.Context,
+	states []string,
+	paginationOpts pagination.Options,
+	teamID int64,
+) ([]*models.Milestone, pagination.Metadata, *validator.Validator, error) {
+	validator := validator.New()
+
+	var filters models.MilestoneFilters
+
+	for _, state := range states {
+		milestoneState, err := models.NewMilestoneState(state)
+		if err != nil {
+			validator.AddError(milestoneStatesField, fmt.Sprintf("Contains an invalid milestone state %q.", state))
+			break
+		}
+
+		filters.States = append(filters.States, milestoneState)
+	}
+
+	if validator.HasErrors() {
+		return nil, pagination.Metadata{}, validator, nil
+	}
+
+	milestones, metadata, err := s.MilestoneRepo.GetAll(ctx, filters, teamID, paginationOpts)
+	return milestones, metadata, nil, err
+}
+
+func (s *MilestoneService) UpdateMilestone(
+	ctx context.Context,
+	update services.MilestoneUpdate,
+	milestone *models.Milestone,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateMilestone, err := isMemberInRole(ctx, s.TeamRepo, milestone.TeamID, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateMilestone {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	newStartsAt := milestone.StartsAt
+	if update.StartsAt != nil {
+		newStartsAt = *update.StartsAt
+	}
+
+	newEndsAt := milestone.EndsAt
+	if update.EndsAt != nil {
+		newEndsAt = *update.EndsAt
+	}
+
+	if update.Name != nil {
+		s.validateName(*update.Name, validator)
+	}
+
+	s.validateDates(newStartsAt, newEndsAt, validator)
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	var isChanged bool
+
+	if update.Name != nil && milestone.Name != *update.Name {
+		milestone.Name = *update.Name
+		isChanged = true
+	}
+
+	if !milestone.StartsAt.Equal(newStartsAt) {
+		milestone.StartsAt = newStartsAt
+		isChanged = true
+	}
+
+	if !milestone.EndsAt.Equal(newEndsAt) {
+		milestone.EndsAt = newEndsAt
+		isChanged = true
+	}
+
+	if !isChanged {
+		return nil, nil
+	}
+
+	if err := s.MilestoneRepo.Update(ctx, milestone); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateMilestoneName):
+			s.addNameTakenError(validator)
+			return validator, nil
+		default:
+			return nil, handleRepositoryUpdateError(err)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *MilestoneService) CloseMilestone(
+	ctx context.Context,
+	milestone *models.Milestone,
+	nextMilestoneID *int64,
+	closerID int64,
+) (int, *validator.Validator, error) {
+	canCloseMilestone, err := isMemberInRole(ctx, s.TeamRepo, milestone.TeamID, closerID, models.MemberRoleLeader)
+	if err != nil {
+		return 0, nil, err
+	}
+
+	if !canCloseMilestone {
+		return 0, nil, services.ErrNoPermission
+	}
+
+	if milestone.State != models.MilestoneStateOpen {
+		return 0, nil, services.ErrMilestoneClosed
+	}
+
+	validator := validator.New()
+
+	if nextMilestoneID != nil {
+		if err := s.validateNextMilestone(ctx, milestone, *nextMilestoneID, validator); err != nil {
+			return 0, nil, err
+		}
+	}
+
+	if validator.HasErrors() {
+		return 0, validator, nil
+	}
+
+	movedTasks, err := s.MilestoneRepo.Close(ctx, milestone, nextMilestoneID, closerID)
+	if err != nil {
+		return 0, nil, handleRepositoryUpdateError(err)
+	}
+
+	return movedTasks, nil, nil
+}
+
+func (s *MilestoneService) DeleteMilestone(ctx context.Context, milestone *models.Milestone, removerID int64) error {
+	canDeleteMilestone, err := isMemberInRole(ctx, s.TeamRepo, milestone.TeamID, removerID, models.MemberRoleLeader)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteMilestone {
+		return services.ErrNoPermission
+	}
+
+	if err := s.MilestoneRepo.Delete(ctx, milestone.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *MilestoneService) validateNextMilestone(
+	ctx context.Context,
+	milestone *models.Milestone,
+	nextMilestoneID int64,
+	validator *validator.Validator,
+) error {
+	if nextMilestoneID == milestone.ID {
+		validator.AddError(milestoneNextMilestoneIDField, "Must not be the milestone being closed.")
+		return nil
+	}
+
+	nextMilestone, err := s.MilestoneRepo.GetByID(ctx, nextMilestoneID, milestone.TeamID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			validator.AddError(milestoneNextMilestoneIDField, "Must refer to an existing milestone in this team.")
+			return nil
+		default:
+			return err
+		}
+	}
+
+	validator.Check(
+		nextMilestone.State == models.MilestoneStateOpen,
+		milestoneNextMilestoneIDField,
+		"Must refer to an open milestone.",
+	)
+
+	return nil
+}
+
+func (s *MilestoneService) validateName(name string, validator *validator.Validator) {
+	validator.CheckNonZero(name, milestoneNameField)
+	validator.CheckStringMaxLength(name, maxMilestoneNameLength, milestoneNameField)
+}
+
+func (s *MilestoneService) validateDates(startsAt, endsAt time.Time, validator *validator.Validator) {
+	validator.CheckNonZero(startsAt, milestoneStartsAtField)
+	validator.CheckNonZero(endsAt, milestoneEndsAtField)
+	validator.Check(endsAt.After(startsAt), milestoneEndsAtField, "Must be after the start date.")
+}
+
+func (s *MilestoneService) addNameTakenError(validator *validator.Validator) {
+	validator.AddError(milestoneNameField, "A milestone with this name already exists in this team.")
+}
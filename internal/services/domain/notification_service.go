@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+)
+
+type NotificationService struct {
+	NotificationRepo repositories.NotificationRepository
+}
+
+func (s *NotificationService) GetNotificationByID(
+	ctx context.Context,
+	notificationID, recipientID int64,
+) (*models.Notification, error) {
+	notification, err := s.NotificationRepo.GetByID(ctx, notificationID, recipientID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return notification, nil
+}
+
+func (s *NotificationService) GetAllNotifications(
+	ctx context.Context,
+	filters models.NotificationFilters,
+	paginationOpts pagination.Options,
+	recipientID int64,
+) ([]*models.Notification, pagination.Metadata, error) {
+	return s.NotificationRepo.GetAll(ctx, filters, recipientID, paginationOpts)
+}
+
+func (s *NotificationService) MarkNotificationAsRead(ctx context.Context, notification *models.Notification) error {
+	if notification.ReadAt != nil {
+		return nil
+	}
+
+	if err := s.NotificationRepo.MarkAsRead(ctx, notification); err != nil {
+		return handleRepositoryUpdateError(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,229 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	projectNameField         = "name"
+	projectDescriptionField  = "description"
+	projectLeadUsernameField = "lead_username"
+
+	maxProjectNameLength        = 64
+	maxProjectDescriptionLength = 1000
+)
+
+type ProjectService struct {
+	ProjectRepo repositories.ProjectRepository
+	TeamRepo    repositories.TeamRepository
+}
+
+func (s *ProjectService) CreateProject(
+	ctx context.Context,
+	name, description, leadUsername string,
+	teamID, creatorID int64,
+) (*models.Project, *validator.Validator, error) {
+	canCreateProject, err := isMemberInRole(ctx, s.TeamRepo, teamID, creatorID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateProject {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateName(name, validator)
+	s.validateDescription(description, validator)
+
+	lead, err := s.resolveLead(ctx, leadUsername, teamID, validator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	project := &models.Project{
+		Name:        name,
+		Description: description,
+		Lead:        lead,
+	}
+
+	if err := s.ProjectRepo.Insert(ctx, project, teamID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateProjectName):
+			s.addNameTakenError(validator)
+			return nil, validator, nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return project, nil, nil
+}
+
+func (s *ProjectService) GetProjectByID(ctx context.Context, projectID, teamID int64) (*models.Project, error) {
+	project, err := s.ProjectRepo.GetByID(ctx, projectID, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return project, nil
+}
+
+func (s *ProjectService) GetAllProjects(
+	ctx context.Context,
+	filters models.ProjectFilters,
+	paginationOpts pagination.Options,
+	teamID int64,
+) ([]*models.Project, pagination.Metadata, error) {
+	return s.ProjectRepo.GetAll(ctx, filters, teamID, paginationOpts)
+}
+
+func (s *ProjectService) UpdateProject(
+	ctx context.Context,
+	update services.ProjectUpdate,
+	project *models.Project,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateProject, err := isMemberInRole(ctx, s.TeamRepo, project.TeamID, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateProject {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if update.Name != nil {
+		s.validateName(*update.Name, validator)
+	}
+
+	if update.Description != nil {
+		s.validateDescription(*update.Description, validator)
+	}
+
+	var newLead *models.User
+
+	if update.LeadUsername != nil {
+		newLead, err = s.resolveLead(ctx, *update.LeadUsername, project.TeamID, validator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	var isChanged bool
+
+	if update.Name != nil && project.Name != *update.Name {
+		project.Name = *update.Name
+		isChanged = true
+	}
+
+	if update.Description != nil && project.Description != *update.Description {
+		project.Description = *update.Description
+		isChanged = true
+	}
+
+	if update.LeadUsername != nil && s.leadUsername(project.Lead) != s.leadUsername(newLead) {
+		project.Lead = newLead
+		isChanged = true
+	}
+
+	if update.IsArchived != nil && project.IsArchived != *update.IsArchived {
+		project.IsArchived = *update.IsArchived
+		isChanged = true
+	}
+
+	if !isChanged {
+		return nil, nil
+	}
+
+	if err := s.ProjectRepo.Update(ctx, project); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateProjectName):
+			s.addNameTakenError(validator)
+			return validator, nil
+		default:
+			return nil, handleRepositoryUpdateError(err)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *ProjectService) DeleteProject(ctx context.Context, project *models.Project, removerID int64) error {
+	canDeleteProject, err := isMemberInRole(ctx, s.TeamRepo, project.TeamID, removerID, models.MemberRoleLeader)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteProject {
+		return services.ErrNoPermission
+	}
+
+	if err := s.ProjectRepo.Delete(ctx, project.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *ProjectService) resolveLead(
+	ctx context.Context,
+	username string,
+	teamID int64,
+	validator *validator.Validator,
+) (*models.User, error) {
+	if username == "" {
+		return nil, nil
+	}
+
+	members, err := s.TeamRepo.GetAllMembersByUsernames(ctx, []string{username}, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(members) == 0 {
+		validator.AddError(projectLeadUsernameField, "Must be a member of this team.")
+		return nil, nil
+	}
+
+	return members[0], nil
+}
+
+func (s *ProjectService) leadUsername(lead *models.User) string {
+	if lead == nil {
+		return ""
+	}
+
+	return lead.Username
+}
+
+func (s *ProjectService) validateName(name string, validator *validator.Validator) {
+	validator.CheckNonZero(name, projectNameField)
+	validator.CheckStringMaxLength(name, maxProjectNameLength, projectNameField)
+}
+
+func (s *ProjectService) validateDescription(description string, validator *validator.Validator) {
+	validator.CheckStringMaxLength(description, maxProjectDescriptionLength, projectDescriptionField)
+}
+
+func (s *ProjectService) addNameTakenError(validator *validator.Validator) {
+	validator.AddError(projectNameField, "A project with this name already exists in this team.")
+}
@@ -0,0 +1,536 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/rrule"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	recurringTaskRRuleField     = "rrule"
+	recurringTaskAssigneesField = "assignee_usernames"
+
+	maxRecurringTaskAssignees = 20
+	maxPreviewedOccurrences   = 50
+	maxGeneratedBatchSize     = 100
+)
+
+type RecurringTaskService struct {
+	RecurringTaskRepo repositories.RecurringTaskRepository
+	TeamRepo          repositories.TeamRepository
+	LabelRepo         repositories.LabelRepository
+}
+
+func (s *RecurringTaskService) CreateRecurringTask(
+	ctx context.Context,
+	title, description, priority string,
+	labels []string,
+	rule string,
+	startsAt time.Time,
+	creator *models.User,
+	assignees []*models.User,
+	teamID int64,
+) (*models.RecurringTask, *validator.Validator, error) {
+	canCreateRecurringTask, err := isMemberInRole(ctx, s.TeamRepo, teamID, creator.ID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateRecurringTask {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	validator.CheckNonZero(title, "title")
+	validator.CheckNonZero(description, "description")
+
+	taskPriority, err := models.NewTaskPriority(priority)
+	if err != nil {
+		validator.AddError("priority", "Must be a valid task priority.")
+	}
+
+	taskLabels, err := resolveLabels(ctx, s.LabelRepo, labels, teamID, validator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validateAssignees(assignees, maxRecurringTaskAssignees, recurringTaskAssigneesField, validator)
+
+	recurrenceRule := s.parseRule(rule, startsAt, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	recurringTask := &models.RecurringTask{
+		Title:       title,
+		Description: description,
+		Priority:    taskPriority,
+		Labels:      labelNames(taskLabels),
+		RRule:       rule,
+		StartsAt:    startsAt,
+		Creator:     creator,
+		Assignees:   assignees,
+	}
+
+	recurringTask.NextOccurrenceAt = s.nextOccurrence(recurrenceRule, timefacade.Instance().Now())
+
+	if recurringTask.NextOccurrenceAt == nil {
+		s.addNoFutureOccurrencesError(validator)
+		return nil, validator, nil
+	}
+
+	if err := s.RecurringTaskRepo.Insert(ctx, recurringTask, teamID); err != nil {
+		return nil, nil, err
+	}
+
+	return recurringTask, nil, nil
+}
+
+func (s *RecurringTaskService) GetRecurringTaskByID(
+	ctx context.Context,
+	recurringTaskID, teamID int64,
+) (*models.RecurringTask, error) {
+	recurringTask, err := s.RecurringTaskRepo.GetByID(ctx, recurringTaskID, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return recurringTask, nil
+}
+
+func (s *RecurringTaskService) GetAllRecurringTasks(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.RecurringTask, pagination.Metadata, error) {
+	return s.RecurringTaskRepo.GetAll(ctx, teamID, paginationOpts)
+}
+
+func (s *RecurringTaskService) UpdateRecurringTask(
+	ctx context.Context,
+	update services.RecurringTaskUpdate,
+	recurringTask *models.RecurringTask,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateRecurringTask, err := isMemberInRole(
+		ctx,
+		s.TeamRepo,
+		recurringTask.TeamID,
+		updaterID,
+		models.MemberRoleLeader,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateRecurringTask {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if update.Title != nil {
+		validator.CheckNonZero(*update.Title, "title")
+	}
+
+	if update.Description != nil {
+		validator.CheckNonZero(*update.Description, "description")
+	}
+
+	var newPriority models.TaskPriority
+
+	if update.Priority != nil {
+		newPriority, err = models.NewTaskPriority(*update.Priority)
+		if err != nil {
+			validator.AddError("priority", "Must be a valid task priority.")
+		}
+	}
+
+	var newLabels []*models.Label
+
+	if update.Labels != nil {
+		newLabels, err = resolveLabels(ctx, s.LabelRepo, *update.Labels, recurringTask.TeamID, validator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if update.Assignees != nil {
+		validateAssignees(*update.Assignees, maxRecurringTaskAssignees, recurringTaskAssigneesField, validator)
+	}
+
+	isScheduleChanged := update.RRule != nil || update.StartsAt != nil
+
+	newRule := recurringTask.RRule
+	if update.RRule != nil {
+		newRule = *update.RRule
+	}
+
+	newStartsAt := recurringTask.StartsAt
+	if update.StartsAt != nil {
+		newStartsAt = *update.StartsAt
+	}
+
+	var recurrenceRule *rrule.Rule
+
+	if isScheduleChanged {
+		recurrenceRule = s.parseRule(newRule, newStartsAt, validator)
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if update.Title != nil {
+		recurringTask.Title = *update.Title
+	}
+
+	if update.Description != nil {
+		recurringTask.Description = *update.Description
+	}
+
+	if update.Priority != nil {
+		recurringTask.Priority = newPriority
+	}
+
+	if update.Labels != nil {
+		recurringTask.Labels = labelNames(newLabels)
+	}
+
+	if update.Assignees != nil {
+		recurringTask.Assignees = *update.Assignees
+	}
+
+	if isScheduleChanged {
+		recurringTask.RRule = newRule
+		recurringTask.StartsAt = newStartsAt
+		recurringTask.NextOccurrenceAt = s.nextOccurrence(recurrenceRule, s.rescheduleFrom(recurringTask))
+
+		if recurringTask.NextOccurrenceAt == nil {
+			s.addNoFutureOccurrencesError(validator)
+			return validator, nil
+		}
+	}
+
+	if err := s.RecurringTaskRepo.Update(ctx, recurringTask); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
+
+func (s *RecurringTaskService) PauseRecurringTask(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	updaterID int64,
+) error {
+	return s.setPaused(ctx, recurringTask, true, updaterID)
+}
+
+func (s *RecurringTaskService) ResumeRecurringTask(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	updaterID int64,
+) error {
+	return s.setPaused(ctx, recurringTask, false, updaterID)
+}
+
+func (s *RecurringTaskService) PreviewOccurrences(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	count int,
+) ([]*models.Occurrence, *validator.Validator, error) {
+	validator := validator.New()
+
+	validator.CheckGreaterThanOrEqualTo(count, 1, "count")
+	validator.CheckLessThanOrEqualTo(count, maxPreviewedOccurrences, "count")
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	recurrenceRule, err := rrule.Parse(recurringTask.RRule, recurringTask.StartsAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	occurrences := []*models.Occurrence{}
+
+	if recurringTask.NextOccurrenceAt == nil {
+		return occurrences, nil, nil
+	}
+
+	after := recurringTask.NextOccurrenceAt.Add(-time.Second)
+	if recurringTask.IsPaused {
+		after = s.rescheduleFrom(recurringTask)
+	}
+
+	for i, at := range recurrenceRule.Next(after, count) {
+		occurrence := &models.Occurrence{At: at}
+
+		if len(recurringTask.Assignees) > 0 {
+			occurrence.Assignee = recurringTask.Assignees[(recurringTask.GeneratedCount+i)%len(recurringTask.Assignees)]
+		}
+
+		occurrences = append(occurrences, occurrence)
+	}
+
+	return occurrences, nil, nil
+}
+
+func (s *RecurringTaskService) DeleteRecurringTask(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	removerID int64,
+) error {
+	canDeleteRecurringTask, err := isMemberInRole(
+		ctx,
+		s.TeamRepo,
+		recurringTask.TeamID,
+		removerID,
+		models.MemberRoleLeader,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteRecurringTask {
+		return services.ErrNoPermission
+	}
+
+	if err := s.RecurringTaskRepo.Delete(ctx, recurringTask.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *RecurringTaskService) GenerateOccurrences(ctx context.Context, lookahead time.Duration) (int, error) {
+	now := timefacade.Instance().Now()
+	horizon := now.Add(lookahead)
+
+	recurringTasks, err := s.RecurringTaskRepo.GetAllDue(ctx, horizon, maxGeneratedBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		generated int
+		errs      []error
+	)
+
+	for _, recurringTask := range recurringTasks {
+		n, err := s.generateOccurrences(ctx, recurringTask, now, horizon)
+		generated += n
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("recurring task %d: %w", recurringTask.ID, err))
+		}
+	}
+
+	return generated, errors.Join(errs...)
+}
+
+func (s *RecurringTaskService) generateOccurrences(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	now, horizon time.Time,
+) (int, error) {
+	recurrenceRule, err := rrule.Parse(recurringTask.RRule, recurringTask.StartsAt)
+	if err != nil {
+		return 0, err
+	}
+
+	labels := []*models.Label{}
+
+	if len(recurringTask.Labels) > 0 {
+		labels, err = s.LabelRepo.GetAllByNames(ctx, recurringTask.Labels, recurringTask.TeamID)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	canCreateTasks, err := isMemberInRole(
+		ctx,
+		s.TeamRepo,
+		recurringTask.TeamID,
+		recurringTask.Creator.ID,
+		models.MemberRoleLeader,
+	)
+	if err != nil {
+		return 0, err
+	}
+
+	var (
+		tasks   = []*models.Task{}
+		skipped []error
+	)
+
+	for _, at := range recurrenceRule.Between(recurringTask.NextOccurrenceAt.Add(-time.Second), horizon) {
+		if !at.After(now) {
+			continue
+		}
+
+		if !canCreateTasks {
+			skipped = append(skipped, fmt.Errorf("occurrence at %s: %w", at.Format(time.RFC3339), services.ErrCreatorNotLeader))
+			continue
+		}
+
+		assignee, err := s.nextAssignee(ctx, recurringTask)
+		if err != nil {
+			return 0, err
+		}
+
+		if assignee == nil {
+			skipped = append(skipped, fmt.Errorf("occurrence at %s: %w", at.Format(time.RFC3339), services.ErrNoActiveAssignee))
+			continue
+		}
+
+		task := &models.Task{
+			Due:         at,
+			Title:       recurringTask.Title,
+			Description: recurringTask.Description,
+			Priority:    recurringTask.Priority,
+			Creator:     recurringTask.Creator,
+			Assignees:   []*models.User{assignee},
+			Labels:      labels,
+		}
+
+		if validator := s.validateOccurrence(task, now); validator.HasErrors() {
+			skipped = append(skipped, fmt.Errorf("occurrence at %s: invalid task: %v", at.Format(time.RFC3339), validator.Errors))
+			continue
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	recurringTask.NextOccurrenceAt = s.nextOccurrence(recurrenceRule, horizon)
+
+	if err := s.RecurringTaskRepo.InsertOccurrences(ctx, recurringTask, tasks); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrEditConflict):
+			return 0, nil
+		default:
+			return 0, err
+		}
+	}
+
+	return len(tasks), errors.Join(skipped...)
+}
+
+func (s *RecurringTaskService) validateOccurrence(task *models.Task, now time.Time) *validator.Validator {
+	validator := validator.New()
+
+	validator.Check(task.Due.After(now), "due", "Must be after the time of creation.")
+	validator.CheckNonZero(task.Title, "title")
+	validator.CheckNonZero(task.Description, "description")
+	validateAssignees(task.Assignees, maxTaskAssignees, taskAssigneesField, validator)
+
+	return validator
+}
+
+func (s *RecurringTaskService) nextAssignee(ctx context.Context, recurringTask *models.RecurringTask) (*models.User, error) {
+	for range recurringTask.Assignees {
+		assignee := recurringTask.Assignees[recurringTask.GeneratedCount%len(recurringTask.Assignees)]
+		recurringTask.GeneratedCount++
+
+		isMember, err := isMemberInRole(ctx, s.TeamRepo, recurringTask.TeamID, assignee.ID, models.MemberRoleRegular)
+		if err != nil {
+			return nil, err
+		}
+
+		if isMember {
+			return assignee, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *RecurringTaskService) setPaused(
+	ctx context.Context,
+	recurringTask *models.RecurringTask,
+	isPaused bool,
+	updaterID int64,
+) error {
+	canUpdateRecurringTask, err := isMemberInRole(
+		ctx,
+		s.TeamRepo,
+		recurringTask.TeamID,
+		updaterID,
+		models.MemberRoleLeader,
+	)
+	if err != nil {
+		return err
+	}
+
+	if !canUpdateRecurringTask {
+		return services.ErrNoPermission
+	}
+
+	if recurringTask.IsPaused == isPaused {
+		return nil
+	}
+
+	if !isPaused && recurringTask.NextOccurrenceAt != nil {
+		recurrenceRule, err := rrule.Parse(recurringTask.RRule, recurringTask.StartsAt)
+		if err != nil {
+			return err
+		}
+
+		recurringTask.NextOccurrenceAt = s.nextOccurrence(recurrenceRule, s.rescheduleFrom(recurringTask))
+	}
+
+	recurringTask.IsPaused = isPaused
+
+	if err := s.RecurringTaskRepo.Update(ctx, recurringTask); err != nil {
+		return handleRepositoryUpdateError(err)
+	}
+
+	return nil
+}
+
+func (s *RecurringTaskService) rescheduleFrom(recurringTask *models.RecurringTask) time.Time {
+	now := timefacade.Instance().Now()
+
+	if recurringTask.NextOccurrenceAt != nil && recurringTask.NextOccurrenceAt.After(now) {
+		return recurringTask.NextOccurrenceAt.Add(-time.Second)
+	}
+
+	return now
+}
+
+func (s *RecurringTaskService) nextOccurrence(recurrenceRule *rrule.Rule, after time.Time) *time.Time {
+	occurrences := recurrenceRule.Next(after, 1)
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	return &occurrences[0]
+}
+
+func (s *RecurringTaskService) parseRule(rule string, startsAt time.Time, validator *validator.Validator) *rrule.Rule {
+	validator.CheckNonZero(startsAt, "starts_at")
+
+	recurrenceRule, err := rrule.Parse(rule, startsAt)
+	if err != nil {
+		validator.AddError(recurringTaskRRuleField, fmt.Sprintf("Must be a valid recurrence rule (%s).", err))
+	}
+
+	return recurrenceRule
+}
+
+func (s *RecurringTaskService) addNoFutureOccurrencesError(validator *validator.Validator) {
+	validator.AddError(recurringTaskRRuleField, "Must produce at least one future occurrence.")
+}
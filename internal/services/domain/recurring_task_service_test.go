@@ -0,0 +1,158 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/services"
+)
+
+func TestGenerateOccurrences(t *testing.T) {
+	const (
+		firstAssigneeID  = 1
+		secondAssigneeID = 2
+		creatorID        = 3
+	)
+
+	now := time.Date(2024, time.June, 3, 8, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		roles         map[int64]models.MemberRole
+		wantAssignees []int64
+		err           error
+	}{
+		{
+			name: "rotates assignees",
+			roles: map[int64]models.MemberRole{
+				firstAssigneeID:  models.MemberRoleRegular,
+				secondAssigneeID: models.MemberRoleRegular,
+				creatorID:        models.MemberRoleLeader,
+			},
+			wantAssignees: []int64{firstAssigneeID, secondAssigneeID},
+		},
+		{
+			name: "skips assignees who left the team",
+			roles: map[int64]models.MemberRole{
+				secondAssigneeID: models.MemberRoleRegular,
+				creatorID:        models.MemberRoleLeader,
+			},
+			wantAssignees: []int64{secondAssigneeID, secondAssigneeID},
+		},
+		{
+			name:  "reports occurrences without assignees",
+			roles: map[int64]models.MemberRole{creatorID: models.MemberRoleLeader},
+			err:   services.ErrNoActiveAssignee,
+		},
+		{
+			name: "reports occurrences of creators who are no longer leaders",
+			roles: map[int64]models.MemberRole{
+				firstAssigneeID:  models.MemberRoleRegular,
+				secondAssigneeID: models.MemberRoleRegular,
+				creatorID:        models.MemberRoleRegular,
+			},
+			err: services.ErrCreatorNotLeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timefacade.SetInstance(&fakeClock{now: now})
+			t.Cleanup(func() { timefacade.SetInstance(nil) })
+
+			nextOccurrenceAt := time.Date(2024, time.June, 3, 9, 0, 0, 0, time.UTC)
+			recurringTask := &models.RecurringTask{
+				ID:               1,
+				Title:            "Standup notes",
+				Description:      "Write down the standup notes.",
+				Priority:         models.TaskPriorityMedium,
+				RRule:            "FREQ=DAILY",
+				StartsAt:         time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC),
+				NextOccurrenceAt: &nextOccurrenceAt,
+				Creator:          &models.User{ID: creatorID},
+				Assignees:        []*models.User{{ID: firstAssigneeID}, {ID: secondAssigneeID}},
+				TeamID:           10,
+			}
+
+			recurringTaskRepo := &fakeRecurringTaskRepo{due: []*models.RecurringTask{recurringTask}}
+			service := &RecurringTaskService{
+				RecurringTaskRepo: recurringTaskRepo,
+				TeamRepo:          &fakeTeamRepo{roles: tt.roles},
+			}
+
+			generated, err := service.GenerateOccurrences(context.Background(), 48*time.Hour)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("GenerateOccurrences() error = %v, want %v", err, tt.err)
+			}
+
+			if generated != len(tt.wantAssignees) {
+				t.Fatalf("GenerateOccurrences() = %d, want %d", generated, len(tt.wantAssignees))
+			}
+
+			var assignees []int64
+			for i, task := range recurringTaskRepo.inserted {
+				wantDue := nextOccurrenceAt.AddDate(0, 0, i)
+				if !task.Due.Equal(wantDue) {
+					t.Fatalf("task %d due = %v, want %v", i, task.Due, wantDue)
+				}
+
+				assignees = append(assignees, task.Assignees[0].ID)
+			}
+
+			if !slices.Equal(assignees, tt.wantAssignees) {
+				t.Fatalf("assignees = %v, want %v", assignees, tt.wantAssignees)
+			}
+
+			wantNext := time.Date(2024, time.June, 5, 9, 0, 0, 0, time.UTC)
+			if recurringTask.NextOccurrenceAt == nil || !recurringTask.NextOccurrenceAt.Equal(wantNext) {
+				t.Fatalf("next occurrence = %v, want %v", recurringTask.NextOccurrenceAt, wantNext)
+			}
+		})
+	}
+}
+
+func TestGenerateOccurrencesSkipsPastOccurrences(t *testing.T) {
+	now := time.Date(2024, time.June, 3, 10, 0, 0, 0, time.UTC)
+	timefacade.SetInstance(&fakeClock{now: now})
+	t.Cleanup(func() { timefacade.SetInstance(nil) })
+
+	nextOccurrenceAt := time.Date(2024, time.June, 1, 9, 0, 0, 0, time.UTC)
+	recurringTask := &models.RecurringTask{
+		Title:            "Backup check",
+		Description:      "Check the nightly backups.",
+		Priority:         models.TaskPriorityLow,
+		RRule:            "FREQ=DAILY",
+		StartsAt:         nextOccurrenceAt,
+		NextOccurrenceAt: &nextOccurrenceAt,
+		Creator:          &models.User{ID: 2},
+		Assignees:        []*models.User{{ID: 1}},
+	}
+
+	recurringTaskRepo := &fakeRecurringTaskRepo{due: []*models.RecurringTask{recurringTask}}
+	service := &RecurringTaskService{
+		RecurringTaskRepo: recurringTaskRepo,
+		TeamRepo: &fakeTeamRepo{roles: map[int64]models.MemberRole{
+			1: models.MemberRoleRegular,
+			2: models.MemberRoleLeader,
+		}},
+	}
+
+	generated, err := service.GenerateOccurrences(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("GenerateOccurrences() error = %v", err)
+	}
+
+	if generated != 1 {
+		t.Fatalf("GenerateOccurrences() = %d, want 1", generated)
+	}
+
+	wantDue := time.Date(2024, time.June, 4, 9, 0, 0, 0, time.UTC)
+	if due := recurringTaskRepo.inserted[0].Due; !due.Equal(wantDue) {
+		t.Fatalf("due = %v, want %v", due, wantDue)
+	}
+}
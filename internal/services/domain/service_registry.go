@@ -1,18 +1,71 @@
 package domain
 
 import (
+	"github.com/svetoslaven/tasktracker/internal/blobstore"
 	"github.com/svetoslaven/tasktracker/internal/repositories"
 	"github.com/svetoslaven/tasktracker/internal/services"
 )
 
-func NewServiceRegistry(repos repositories.RepositoryRegistry) services.ServiceRegistry {
+func NewServiceRegistry(repos repositories.RepositoryRegistry, blobStore blobstore.BlobStore) services.ServiceRegistry {
 	return services.ServiceRegistry{
 		UserService:  &UserService{UserRepo: repos.UserRepo},
 		TokenService: &TokenService{TokenRepo: repos.TokenRepo},
 		TeamService:  &TeamService{TeamRepo: repos.TeamRepo},
+		WorkflowService: &WorkflowService{
+			WorkflowRepo: repos.WorkflowRepo,
+			TeamRepo:     repos.TeamRepo,
+		},
 		TaskService: &TaskService{
-			TaskRepo: repos.TaskRepo,
-			TeamRepo: repos.TeamRepo,
+			TaskRepo:      repos.TaskRepo,
+			TeamRepo:      repos.TeamRepo,
+			WorkflowRepo:  repos.WorkflowRepo,
+			LabelRepo:     repos.LabelRepo,
+			MilestoneRepo: repos.MilestoneRepo,
+			ProjectRepo:   repos.ProjectRepo,
+		},
+		CommentService: &CommentService{
+			CommentRepo: repos.CommentRepo,
+			TeamRepo:    repos.TeamRepo,
+		},
+		AttachmentService: &AttachmentService{
+			AttachmentRepo: repos.AttachmentRepo,
+			TeamRepo:       repos.TeamRepo,
+			BlobStore:      blobStore,
+		},
+		TimeEntryService: &TimeEntryService{
+			TimeEntryRepo: repos.TimeEntryRepo,
+			TeamRepo:      repos.TeamRepo,
+		},
+		ExtensionService: &DueDateExtensionService{
+			ExtensionRepo: repos.ExtensionRepo,
+			TeamRepo:      repos.TeamRepo,
+		},
+		LabelService: &LabelService{
+			LabelRepo: repos.LabelRepo,
+			TeamRepo:  repos.TeamRepo,
+		},
+		TaskViewService: &TaskViewService{
+			TaskViewRepo: repos.TaskViewRepo,
+			TeamRepo:     repos.TeamRepo,
+		},
+		RecurringTaskService: &RecurringTaskService{
+			RecurringTaskRepo: repos.RecurringTaskRepo,
+			TeamRepo:          repos.TeamRepo,
+			LabelRepo:         repos.LabelRepo,
+		},
+		TaskTemplateService: &TaskTemplateService{
+			TaskTemplateRepo: repos.TaskTemplateRepo,
+			TeamRepo:         repos.TeamRepo,
+			LabelRepo:        repos.LabelRepo,
+		},
+		NotificationService: &NotificationService{NotificationRepo: repos.NotificationRepo},
+		MilestoneService: &MilestoneService{
+			MilestoneRepo: repos.MilestoneRepo,
+			TeamRepo:      repos.TeamRepo,
+		},
+		ProjectService: &ProjectService{
+			ProjectRepo: repos.ProjectRepo,
+			TeamRepo:    repos.TeamRepo,
 		},
 	}
 }
@@ -2,20 +2,58 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
 	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
 	"github.com/svetoslaven/tasktracker/internal/models"
 	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/rank"
 	"github.com/svetoslaven/tasktracker/internal/repositories"
 	"github.com/svetoslaven/tasktracker/internal/services"
 	"github.com/svetoslaven/tasktracker/internal/validator"
 )
 
+const (
+	taskParentIDField    = "parent_id"
+	taskMilestoneIDField = "milestone_id"
+	taskProjectIDField   = "project_id"
+	taskBlockerIDField   = "blocker_id"
+	taskLabelsField      = "labels"
+	taskAssigneesField   = "assignee_usernames"
+	taskBulkItemsField   = "tasks"
+	taskImportRowsField  = "rows"
+
+	taskAfterTaskIDField  = "after_task_id"
+	taskBeforeTaskIDField = "before_task_id"
+
+	taskEstimateField     = "estimate"
+	taskEstimateUnitField = "estimate_unit"
+
+	maxTaskDepth     = 5
+	maxBulkTasks     = 100
+	maxTaskEstimate  = 10000
+	maxTaskAssignees = 10
+	maxImportTasks   = 500
+
+	maxVelocityPeriods      = 52
+	maxIterationLengthDays  = 90
+	velocityPeriodWeek      = "week"
+	velocityPeriodIteration = "iteration"
+)
+
 type TaskService struct {
-	TaskRepo repositories.TaskRepository
-	TeamRepo repositories.TeamRepository
+	TaskRepo      repositories.TaskRepository
+	TeamRepo      repositories.TeamRepository
+	WorkflowRepo  repositories.WorkflowRepository
+	LabelRepo     repositories.LabelRepository
+	MilestoneRepo repositories.MilestoneRepository
+	ProjectRepo   repositories.ProjectRepository
 }
 
 func (s *TaskService) CreateTask(
@@ -23,35 +61,43 @@ func (s *TaskService) CreateTask(
 	due time.Time,
 	title, description string,
 	priority string,
-	creator, assignee *models.User,
+	creator *models.User,
+	assignees []*models.User,
 	teamID int64,
+	parentID *int64,
+	milestoneID *int64,
+	projectID *int64,
+	labels []string,
+	estimate float64,
+	estimateUnit string,
 ) (*models.Task, *validator.Validator, error) {
 	validator := validator.New()
 
-	validator.Check(due.After(timefacade.Instance().Now()), "due", "Must be after the time of creation.")
-
-	validator.CheckNonZero(title, "title")
-	validator.CheckNonZero(description, "description")
-
-	taskPriority, err := models.NewTaskPriority(priority)
+	task, err := s.newTask(
+		ctx,
+		due,
+		title,
+		description,
+		priority,
+		creator,
+		assignees,
+		teamID,
+		parentID,
+		milestoneID,
+		projectID,
+		labels,
+		estimate,
+		estimateUnit,
+		validator,
+	)
 	if err != nil {
-		validator.AddError("priority", "Must be a valid task priority.")
+		return nil, nil, err
 	}
 
 	if validator.HasErrors() {
 		return nil, validator, nil
 	}
 
-	task := &models.Task{
-		Due:         due,
-		Title:       title,
-		Description: description,
-		Status:      models.TaskStatusOpen,
-		Priority:    taskPriority,
-		Creator:     creator,
-		Assignee:    assignee,
-	}
-
 	creatorRole, err := s.TeamRepo.GetMemberRole(ctx, teamID, creator.ID)
 	if err != nil {
 		return nil, nil, err
@@ -61,17 +107,90 @@ func (s *TaskService) CreateTask(
 		return nil, nil, services.ErrNoPermission
 	}
 
-	if err := s.TaskRepo.Insert(ctx, task, creator.ID, assignee.ID, teamID); err != nil {
+	if err := s.TaskRepo.Insert(ctx, task, creator.ID, teamID); err != nil {
 		return nil, nil, err
 	}
 
 	return task, nil, nil
 }
 
+func (s *TaskService) newTask(
+	ctx context.Context,
+	due time.Time,
+	title, description string,
+	priority string,
+	creator *models.User,
+	assignees []*models.User,
+	teamID int64,
+	parentID *int64,
+	milestoneID *int64,
+	projectID *int64,
+	labels []string,
+	estimate float64,
+	estimateUnit string,
+	validator *validator.Validator,
+) (*models.Task, error) {
+	s.validateDue(due, validator)
+	s.validateTitle(title, validator)
+	s.validateDescription(description, validator)
+	validateAssignees(assignees, maxTaskAssignees, taskAssigneesField, validator)
+
+	taskPriority := s.parsePriority(priority, validator)
+	taskEstimate := s.parseEstimate(estimate, estimateUnit, validator)
+
+	if parentID != nil {
+		if err := s.validateParent(ctx, nil, *parentID, teamID, validator); err != nil {
+			return nil, err
+		}
+	}
+
+	if milestoneID != nil {
+		if err := s.validateMilestone(ctx, *milestoneID, teamID, validator); err != nil {
+			return nil, err
+		}
+	}
+
+	if projectID != nil {
+		if err := s.validateProject(ctx, *projectID, teamID, validator); err != nil {
+			return nil, err
+		}
+	}
+
+	taskLabels, err := resolveLabels(ctx, s.LabelRepo, labels, teamID, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	taskMentions, err := resolveMentions(ctx, s.TeamRepo, description, "description", teamID, validator)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Task{
+		Due:         due,
+		Title:       title,
+		Description: description,
+		Priority:    taskPriority,
+		Creator:     creator,
+		Assignees:   assignees,
+		ParentID:    parentID,
+		MilestoneID: milestoneID,
+		ProjectID:   projectID,
+		Labels:      taskLabels,
+		Mentions:    taskMentions,
+		Estimate:    taskEstimate,
+	}, nil
+}
+
 func (s *TaskService) GetTaskByID(ctx context.Context, taskID, teamID int64) (*models.Task, error) {
-	task, err := s.TaskRepo.GetByID(ctx, taskID, teamID)
+	task, err := s.getTaskByID(ctx, taskID, teamID, false)
 	if err != nil {
-		return nil, handleRepositoryRetrievalError(err)
+		return nil, err
+	}
+
+	task.BlockedBy, task.Blocks, err = s.TaskRepo.GetDependencies(ctx, task.ID)
+	if err != nil {
+		return nil, err
 	}
 
 	return task, nil
@@ -86,16 +205,293 @@ func (s *TaskService) GetAllTasks(
 ) ([]*models.Task, pagination.Metadata, *validator.Validator, error) {
 	validator := validator.New()
 
-	for _, s := range status {
-		taskStatus, err := models.NewTaskStatus(s)
+	if len(status) > 0 {
+		workflow, err := getWorkflow(ctx, s.WorkflowRepo, teamID)
+		if err != nil {
+			return nil, pagination.Metadata{}, nil, err
+		}
+
+		for _, name := range status {
+			if findWorkflowStatus(workflow, name) == nil {
+				validator.AddError("status", fmt.Sprintf("Contains a status %q that is not part of the team workflow", name))
+				break
+			}
+
+			filters.Status = append(filters.Status, name)
+		}
+	}
+
+	s.prepareTaskFilters(&filters, priority, validator)
+
+	validator.Check(
+		!paginationOpts.HasSortColumn("relevance") || filters.Query != "",
+		pagination.SortKey,
+		"Sorting by relevance requires a search query.",
+	)
+
+	if validator.HasErrors() {
+		return nil, pagination.Metadata{}, validator, nil
+	}
+
+	tasks, metadata, err := s.TaskRepo.GetAll(ctx, filters, teamID, paginationOpts)
+	return tasks, metadata, nil, err
+}
+
+func (s *TaskService) UpdateTaskStatus(
+	ctx context.Context,
+	task *models.Task,
+	newStatus string,
+	updaterID int64,
+	force bool,
+) (*validator.Validator, error) {
+	workflow, err := getWorkflow(ctx, s.WorkflowRepo, task.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := findWorkflowStatus(workflow, newStatus)
+	if status == nil {
+		validator := validator.New()
+		validator.AddError("status", "Must be a status in the team workflow.")
+		return validator, nil
+	}
+
+	return nil, s.updateTaskStatus(ctx, task, workflow, status, updaterID, force)
+}
+
+func (s *TaskService) UpdateTaskStatusCategory(
+	ctx context.Context,
+	task *models.Task,
+	category models.StatusCategory,
+	updaterID int64,
+	force bool,
+) (*validator.Validator, error) {
+	workflow, err := getWorkflow(ctx, s.WorkflowRepo, task.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := findWorkflowStatusInCategory(workflow, task.Status.Name, category)
+	if status == nil {
+		validator := validator.New()
+		validator.AddError("status", fmt.Sprintf("The team workflow has no %s status.", category))
+		return validator, nil
+	}
+
+	return nil, s.updateTaskStatus(ctx, task, workflow, status, updaterID, force)
+}
+
+func (s *TaskService) updateTaskStatus(
+	ctx context.Context,
+	task *models.Task,
+	workflow *models.Workflow,
+	status *models.WorkflowStatus,
+	updaterID int64,
+	force bool,
+) error {
+	team, err := s.getTeamByID(ctx, task.TeamID)
+	if err != nil {
+		return err
+	}
+
+	taskStatus := models.TaskStatus{ID: status.ID, Name: status.Name, Category: status.Category}
+
+	err = s.checkStatusChange(ctx, task, workflow, team.OverduePolicy, taskStatus, updaterID, force)
+	if err != nil {
+		return err
+	}
+
+	if err := s.TaskRepo.UpdateTaskStatus(ctx, task, taskStatus, updaterID); err != nil {
+		return handleRepositoryUpdateError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskService) MoveTask(
+	ctx context.Context,
+	task *models.Task,
+	move services.TaskMove,
+	updaterID int64,
+) (*validator.Validator, error) {
+	validator := validator.New()
+
+	validator.Check(
+		move.AfterTaskID == nil || move.BeforeTaskID == nil,
+		taskBeforeTaskIDField,
+		"Must not be set together with after_task_id.",
+	)
+
+	workflow, err := getWorkflow(ctx, s.WorkflowRepo, task.TeamID)
+	if err != nil {
+		return nil, err
+	}
+
+	newStatus := task.Status
+
+	if move.Status != "" && move.Status != task.Status.Name {
+		status := findWorkflowStatus(workflow, move.Status)
+		if status == nil {
+			validator.AddError("status", "Must be a status in the team workflow.")
+		} else {
+			newStatus = models.TaskStatus{ID: status.ID, Name: status.Name, Category: status.Category}
+		}
+	}
+
+	anchorID, anchorField, isBefore := move.AfterTaskID, taskAfterTaskIDField, false
+	if move.BeforeTaskID != nil {
+		anchorID, anchorField, isBefore = move.BeforeTaskID, taskBeforeTaskIDField, true
+	}
+
+	var anchor *models.Task
+
+	if anchorID != nil && !validator.HasErrors() {
+		anchor, err = s.TaskRepo.GetByID(ctx, *anchorID, task.TeamID, false)
+		if err != nil {
+			switch {
+			case errors.Is(err, repositories.ErrNoRecordsFound):
+				validator.AddError(anchorField, "Must refer to an existing task in this team.")
+			default:
+				return nil, err
+			}
+		} else {
+			validator.Check(anchor.ID != task.ID, anchorField, "Must refer to a task other than the moved one.")
+			validator.Check(anchor.Status.ID == newStatus.ID, anchorField, "Must refer to a task in the target status.")
+		}
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if newStatus.ID != task.Status.ID {
+		team, err := s.getTeamByID(ctx, task.TeamID)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.checkStatusChange(ctx, task, workflow, team.OverduePolicy, newStatus, updaterID, move.Force)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		canMoveTask, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleRegular)
 		if err != nil {
-			validator.AddError("status", fmt.Sprintf("Contains an invalid task status %q", s))
+			return nil, err
+		}
+
+		if !canMoveTask {
+			return nil, services.ErrNoPermission
+		}
+	}
+
+	if anchor == nil {
+		if err := s.TaskRepo.UpdateTaskStatus(ctx, task, newStatus, updaterID); err != nil {
+			return nil, handleRepositoryUpdateError(err)
+		}
+
+		return nil, nil
+	}
+
+	adjacentRank, err := s.TaskRepo.GetAdjacentRank(ctx, anchor, isBefore, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	prevRank, nextRank := anchor.Rank, adjacentRank
+	if isBefore {
+		prevRank, nextRank = adjacentRank, anchor.Rank
+	}
+
+	var newRank string
+
+	if nextRank == "" {
+		newRank, err = rank.After(prevRank)
+	} else {
+		newRank, err = rank.Between(prevRank, nextRank)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, rank.ErrInvalidRange):
+			return nil, services.ErrEditConflict
+		default:
+			return nil, err
+		}
+	}
+
+	if err := s.TaskRepo.Move(ctx, task, newStatus, newRank, updaterID); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
+
+func (s *TaskService) GetBoard(
+	ctx context.Context,
+	filters models.TaskFilters,
+	status, priority []string,
+	paginationOpts pagination.Options,
+	teamID int64,
+) ([]*models.BoardColumn, *validator.Validator, error) {
+	validator := validator.New()
+
+	workflow, err := getWorkflow(ctx, s.WorkflowRepo, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, name := range status {
+		if findWorkflowStatus(workflow, name) == nil {
+			validator.AddError("status", fmt.Sprintf("Contains a status %q that is not part of the team workflow", name))
 			break
 		}
+	}
+
+	s.prepareTaskFilters(&filters, priority, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	counts, err := s.TaskRepo.CountByStatus(ctx, filters, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	columns := []*models.BoardColumn{}
+
+	for _, workflowStatus := range workflow.Statuses {
+		if len(status) > 0 && !slices.Contains(status, workflowStatus.Name) {
+			continue
+		}
+
+		columnFilters := filters
+		columnFilters.Status = []string{workflowStatus.Name}
+
+		tasks, _, err := s.TaskRepo.GetAll(ctx, columnFilters, teamID, paginationOpts)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		count := counts[workflowStatus.Name]
 
-		filters.Status = append(filters.Status, taskStatus)
+		columns = append(columns, &models.BoardColumn{
+			Status:   workflowStatus.Name,
+			Category: workflowStatus.Category,
+			Count:    count,
+			Tasks:    tasks,
+			Metadata: pagination.CalculateMetadata(paginationOpts.Page(), paginationOpts.PageSize(), count),
+		})
 	}
 
+	return columns, nil, nil
+}
+
+func (s *TaskService) prepareTaskFilters(
+	filters *models.TaskFilters,
+	priority []string,
+	validator *validator.Validator,
+) {
 	for _, p := range priority {
 		taskPriority, err := models.NewTaskPriority(p)
 		if err != nil {
@@ -106,84 +502,1249 @@ func (s *TaskService) GetAllTasks(
 		filters.Priority = append(filters.Priority, taskPriority)
 	}
 
-	if validator.HasErrors() {
-		return nil, pagination.Metadata{}, validator, nil
-	}
+	filters.Labels = uniqueLabelNames(filters.Labels)
 
-	tasks, metadata, err := s.TaskRepo.GetAll(ctx, filters, teamID, paginationOpts)
-	return tasks, metadata, nil, err
+	validator.CheckStringMaxLength(filters.Query, 256, "q")
 }
 
-func (s *TaskService) UpdateTaskStatus(
+func (s *TaskService) checkStatusChange(
 	ctx context.Context,
 	task *models.Task,
+	workflow *models.Workflow,
+	overduePolicy models.OverduePolicy,
 	newStatus models.TaskStatus,
 	updaterID int64,
+	force bool,
 ) error {
-	switch newStatus {
-	case models.TaskStatusInProgress:
-		return s.startTask(ctx, task, updaterID)
-	case models.TaskStatusCompleted:
-		return s.completeTask(ctx, task, updaterID)
-	case models.TaskStatusCancelled:
-		return s.cancelTask(ctx, task, updaterID)
+	transition := findWorkflowTransition(workflow, task.Status.Name, newStatus.Name)
+	if transition == nil {
+		return services.ErrTaskStatusConflict
+	}
+
+	isAllowed, err := s.isTransitionActor(ctx, task, transition, updaterID)
+	if err != nil {
+		return err
+	}
+
+	if !isAllowed {
+		return services.ErrNoPermission
+	}
+
+	switch newStatus.Category {
+	case models.StatusCategoryDoing:
+		return s.checkTaskStart(ctx, task, overduePolicy)
+	case models.StatusCategoryDone:
+		return s.checkTaskCompletion(task, overduePolicy, force)
 	default:
-		panic("invalid task status for update")
+		return nil
 	}
 }
 
-func (s *TaskService) startTask(ctx context.Context, task *models.Task, updaterID int64) error {
-	if updaterID != task.Assignee.ID {
-		return services.ErrNoPermission
+func (s *TaskService) isTransitionActor(
+	ctx context.Context,
+	task *models.Task,
+	transition *models.WorkflowTransition,
+	updaterID int64,
+) (bool, error) {
+	for _, actor := range transition.Actors {
+		switch actor {
+		case models.TransitionActorCreator:
+			if task.Creator.ID == updaterID {
+				return true, nil
+			}
+		case models.TransitionActorAssignee:
+			if isTaskAssignee(task, updaterID) {
+				return true, nil
+			}
+		case models.TransitionActorLeader:
+			isLeader, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleLeader)
+			if err != nil || isLeader {
+				return isLeader, err
+			}
+		}
 	}
 
-	if task.Due.Before(timefacade.Instance().Now()) {
-		return services.ErrTaskOverdue
+	return false, nil
+}
+
+func (s *TaskService) checkTaskStart(
+	ctx context.Context,
+	task *models.Task,
+	overduePolicy models.OverduePolicy,
+) error {
+	if err := s.checkOverdue(task, overduePolicy); err != nil {
+		return err
 	}
 
-	if task.Status != models.TaskStatusOpen {
-		return services.ErrTaskStatusConflict
+	blockedBy, _, err := s.TaskRepo.GetDependencies(ctx, task.ID)
+	if err != nil {
+		return err
 	}
 
-	if err := s.TaskRepo.UpdateTaskStatus(ctx, task, models.TaskStatusInProgress); err != nil {
-		return handleRepositoryUpdateError(err)
+	for _, blocker := range blockedBy {
+		if blocker.Status.Category != models.StatusCategoryDone &&
+			blocker.Status.Category != models.StatusCategoryCancelled {
+			return services.ErrTaskBlocked
+		}
 	}
 
 	return nil
 }
 
-func (s *TaskService) completeTask(ctx context.Context, task *models.Task, updaterID int64) error {
-	if updaterID != task.Creator.ID {
-		return services.ErrNoPermission
+func (s *TaskService) checkTaskCompletion(
+	task *models.Task,
+	overduePolicy models.OverduePolicy,
+	force bool,
+) error {
+	if err := s.checkOverdue(task, overduePolicy); err != nil {
+		return err
+	}
+
+	if !force && task.Progress != nil && task.Progress.Completed < task.Progress.Total {
+		return services.ErrTaskHasOpenSubtasks
+	}
+
+	return nil
+}
+
+func (s *TaskService) checkOverdue(task *models.Task, overduePolicy models.OverduePolicy) error {
+	if !task.Due.Before(timefacade.Instance().Now()) {
+		return nil
 	}
 
-	if task.Due.Before(timefacade.Instance().Now()) {
+	switch overduePolicy {
+	case models.OverduePolicyAllow:
+		return nil
+	case models.OverduePolicyFlag:
+		task.IsLate = true
+		return nil
+	default:
 		return services.ErrTaskOverdue
 	}
+}
 
-	if task.Status != models.TaskStatusInProgress {
-		return services.ErrTaskStatusConflict
+func (s *TaskService) UpdateTask(
+	ctx context.Context,
+	update services.TaskUpdate,
+	task *models.Task,
+	updaterID int64,
+) (*validator.Validator, error) {
+	validator := validator.New()
+
+	if update.Due != nil {
+		s.validateDue(*update.Due, validator)
 	}
 
-	if err := s.TaskRepo.UpdateTaskStatus(ctx, task, models.TaskStatusCompleted); err != nil {
-		return handleRepositoryUpdateError(err)
+	if update.Title != nil {
+		s.validateTitle(*update.Title, validator)
 	}
 
-	return nil
-}
+	if update.Description != nil {
+		s.validateDescription(*update.Description, validator)
+	}
 
-func (s *TaskService) cancelTask(ctx context.Context, task *models.Task, updaterID int64) error {
-	if updaterID != task.Creator.ID {
-		return services.ErrNoPermission
+	var newPriority models.TaskPriority
+
+	if update.Assignees != nil {
+		validateAssignees(*update.Assignees, maxTaskAssignees, taskAssigneesField, validator)
 	}
 
-	if task.Status != models.TaskStatusOpen && task.Status != models.TaskStatusInProgress {
-		return services.ErrTaskStatusConflict
+	if update.Priority != nil {
+		newPriority = s.parsePriority(*update.Priority, validator)
 	}
 
-	if err := s.TaskRepo.UpdateTaskStatus(ctx, task, models.TaskStatusCancelled); err != nil {
-		return handleRepositoryUpdateError(err)
+	if update.ParentID != nil && *update.ParentID != 0 {
+		if err := s.validateParent(ctx, task, *update.ParentID, task.TeamID, validator); err != nil {
+			return nil, err
+		}
 	}
 
-	return nil
+	if update.MilestoneID != nil && *update.MilestoneID != 0 {
+		if err := s.validateMilestone(ctx, *update.MilestoneID, task.TeamID, validator); err != nil {
+			return nil, err
+		}
+	}
+
+	if update.ProjectID != nil && *update.ProjectID != 0 {
+		if err := s.validateProject(ctx, *update.ProjectID, task.TeamID, validator); err != nil {
+			return nil, err
+		}
+	}
+
+	newEstimate := task.Estimate
+
+	switch {
+	case update.Estimate != nil:
+		unit := ""
+
+		switch {
+		case update.EstimateUnit != nil:
+			unit = *update.EstimateUnit
+		case task.Estimate != nil:
+			unit = task.Estimate.Unit.String()
+		}
+
+		newEstimate = s.parseEstimate(*update.Estimate, unit, validator)
+	case update.EstimateUnit != nil && task.Estimate != nil:
+		newEstimate = s.parseEstimate(task.Estimate.Value, *update.EstimateUnit, validator)
+	case update.EstimateUnit != nil:
+		validator.AddError(taskEstimateUnitField, "Must be set together with an estimate.")
+	}
+
+	var newMentions []*models.User
+
+	if update.Description != nil {
+		var err error
+
+		newMentions, err = resolveMentions(ctx, s.TeamRepo, *update.Description, "description", task.TeamID, validator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var newLabels []*models.Label
+
+	if update.Labels != nil {
+		var err error
+
+		newLabels, err = resolveLabels(ctx, s.LabelRepo, *update.Labels, task.TeamID, validator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	canUpdateTask, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateTask {
+		return nil, services.ErrNoPermission
+	}
+
+	if update.Version != task.Version {
+		return nil, services.ErrEditConflict
+	}
+
+	var changes []models.TaskChange
+
+	if update.Due != nil && !task.Due.Equal(*update.Due) {
+		changes = append(changes, models.TaskChange{
+			Field:    "due",
+			OldValue: task.Due.Format(time.RFC3339),
+			NewValue: update.Due.Format(time.RFC3339),
+		})
+		task.Due = *update.Due
+	}
+
+	if update.Title != nil && task.Title != *update.Title {
+		changes = append(changes, models.TaskChange{Field: "title", OldValue: task.Title, NewValue: *update.Title})
+		task.Title = *update.Title
+	}
+
+	if update.Description != nil && task.Description != *update.Description {
+		changes = append(changes, models.TaskChange{
+			Field:    "description",
+			OldValue: task.Description,
+			NewValue: *update.Description,
+		})
+		task.Description = *update.Description
+		task.Mentions = newMentions
+	}
+
+	if update.Priority != nil && task.Priority != newPriority {
+		changes = append(changes, models.TaskChange{
+			Field:    "priority",
+			OldValue: task.Priority.String(),
+			NewValue: newPriority.String(),
+		})
+		task.Priority = newPriority
+	}
+
+	if update.Assignees != nil && s.formatUsers(task.Assignees) != s.formatUsers(*update.Assignees) {
+		changes = append(changes, models.TaskChange{
+			Field:    "assignees",
+			OldValue: s.formatUsers(task.Assignees),
+			NewValue: s.formatUsers(*update.Assignees),
+		})
+		task.Assignees = *update.Assignees
+	}
+
+	if update.ParentID != nil {
+		var newParentID *int64
+
+		if *update.ParentID != 0 {
+			newParentID = update.ParentID
+		}
+
+		if s.formatID(task.ParentID) != s.formatID(newParentID) {
+			changes = append(changes, models.TaskChange{
+				Field:    "parent",
+				OldValue: s.formatID(task.ParentID),
+				NewValue: s.formatID(newParentID),
+			})
+			task.ParentID = newParentID
+		}
+	}
+
+	if update.MilestoneID != nil {
+		var newMilestoneID *int64
+
+		if *update.MilestoneID != 0 {
+			newMilestoneID = update.MilestoneID
+		}
+
+		if s.formatID(task.MilestoneID) != s.formatID(newMilestoneID) {
+			changes = append(changes, models.TaskChange{
+				Field:    "milestone",
+				OldValue: s.formatID(task.MilestoneID),
+				NewValue: s.formatID(newMilestoneID),
+			})
+			task.MilestoneID = newMilestoneID
+		}
+	}
+
+	if update.ProjectID != nil {
+		var newProjectID *int64
+
+		if *update.ProjectID != 0 {
+			newProjectID = update.ProjectID
+		}
+
+		if s.formatID(task.ProjectID) != s.formatID(newProjectID) {
+			changes = append(changes, models.TaskChange{
+				Field:    "project",
+				OldValue: s.formatID(task.ProjectID),
+				NewValue: s.formatID(newProjectID),
+			})
+			task.ProjectID = newProjectID
+		}
+	}
+
+	if update.Labels != nil && s.formatLabels(task.Labels) != s.formatLabels(newLabels) {
+		changes = append(changes, models.TaskChange{
+			Field:    "labels",
+			OldValue: s.formatLabels(task.Labels),
+			NewValue: s.formatLabels(newLabels),
+		})
+		task.Labels = newLabels
+	}
+
+	if s.formatEstimate(task.Estimate) != s.formatEstimate(newEstimate) {
+		changes = append(changes, models.TaskChange{
+			Field:    "estimate",
+			OldValue: s.formatEstimate(task.Estimate),
+			NewValue: s.formatEstimate(newEstimate),
+		})
+		task.Estimate = newEstimate
+	}
+
+	if len(changes) == 0 {
+		return nil, nil
+	}
+
+	if err := s.TaskRepo.Update(ctx, task, changes, updaterID); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
+
+func (s *TaskService) BulkUpdateTasks(
+	ctx context.Context,
+	items []services.BulkTaskItem,
+	operation services.BulkTaskOperation,
+	isAtomic bool,
+	teamID, updaterID int64,
+) ([]*services.BulkTaskResult, *validator.Validator, error) {
+	validator := validator.New()
+
+	validator.Check(len(items) > 0, taskBulkItemsField, "Must contain at least one task.")
+	validator.Check(
+		len(items) <= maxBulkTasks,
+		taskBulkItemsField,
+		fmt.Sprintf("Must not contain more than %d tasks.", maxBulkTasks),
+	)
+
+	taskIDs := make([]int64, 0, len(items))
+	for _, item := range items {
+		taskIDs = append(taskIDs, item.TaskID)
+	}
+
+	slices.Sort(taskIDs)
+	validator.Check(len(slices.Compact(taskIDs)) == len(items), taskBulkItemsField, "Must not contain duplicate task IDs.")
+
+	var (
+		workflow      *models.Workflow
+		overduePolicy models.OverduePolicy
+		newStatus     models.TaskStatus
+		newPriority   models.TaskPriority
+	)
+
+	switch operation.Action {
+	case services.BulkTaskActionChangeStatus:
+		var err error
+
+		workflow, err = getWorkflow(ctx, s.WorkflowRepo, teamID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		status := findWorkflowStatus(workflow, operation.Status)
+		validator.Check(status != nil, "status", "Must be a status in the team workflow.")
+
+		if status != nil {
+			newStatus = models.TaskStatus{ID: status.ID, Name: status.Name, Category: status.Category}
+		}
+
+		team, err := s.getTeamByID(ctx, teamID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		overduePolicy = team.OverduePolicy
+	case services.BulkTaskActionReassign:
+		validateAssignees(operation.Assignees, maxTaskAssignees, taskAssigneesField, validator)
+	case services.BulkTaskActionChangePriority:
+		newPriority = s.parsePriority(operation.Priority, validator)
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	results := make([]*services.BulkTaskResult, 0, len(items))
+
+	var (
+		mutations       []*repositories.TaskMutation
+		mutationResults []*services.BulkTaskResult
+		hasFailures     bool
+	)
+
+	for _, item := range items {
+		result := &services.BulkTaskResult{TaskID: item.TaskID}
+		results = append(results, result)
+
+		mutation, err := s.prepareBulkMutation(
+			ctx,
+			item,
+			teamID,
+			operation,
+			workflow,
+			overduePolicy,
+			newStatus,
+			newPriority,
+			updaterID,
+		)
+		if err != nil {
+			if !s.isBulkItemError(err) {
+				return nil, nil, err
+			}
+
+			result.Err = err
+			hasFailures = true
+
+			continue
+		}
+
+		if mutation == nil {
+			result.IsApplied = true
+			continue
+		}
+
+		result.Task = mutation.Task
+		result.OldStatus = mutation.Task.Status.Name
+
+		mutations = append(mutations, mutation)
+		mutationResults = append(mutationResults, result)
+	}
+
+	if !(isAtomic && hasFailures) && len(mutations) > 0 {
+		errs, err := s.TaskRepo.ApplyMutations(ctx, mutations, updaterID, isAtomic)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		for i, result := range mutationResults {
+			if errs[i] != nil {
+				result.Err = handleRepositoryUpdateError(errs[i])
+				hasFailures = true
+			} else {
+				result.IsApplied = true
+			}
+		}
+	}
+
+	if isAtomic && hasFailures {
+		for _, result := range results {
+			result.IsApplied = false
+		}
+	}
+
+	return results, nil, nil
+}
+
+func (s *TaskService) prepareBulkMutation(
+	ctx context.Context,
+	item services.BulkTaskItem,
+	teamID int64,
+	operation services.BulkTaskOperation,
+	workflow *models.Workflow,
+	overduePolicy models.OverduePolicy,
+	newStatus models.TaskStatus,
+	newPriority models.TaskPriority,
+	updaterID int64,
+) (*repositories.TaskMutation, error) {
+	task, err := s.getTaskByID(ctx, item.TaskID, teamID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if task.Version != item.Version {
+		return nil, services.ErrEditConflict
+	}
+
+	switch operation.Action {
+	case services.BulkTaskActionChangeStatus:
+		err := s.checkStatusChange(ctx, task, workflow, overduePolicy, newStatus, updaterID, operation.Force)
+		if err != nil {
+			return nil, err
+		}
+
+		return &repositories.TaskMutation{
+			Kind:      repositories.TaskMutationStatusChange,
+			Task:      task,
+			NewStatus: newStatus,
+		}, nil
+	case services.BulkTaskActionDelete:
+		canTrashTask, err := s.isCreatorOrMemberInRole(ctx, task, updaterID, models.MemberRoleAdmin)
+		if err != nil {
+			return nil, err
+		}
+
+		if !canTrashTask {
+			return nil, services.ErrNoPermission
+		}
+
+		return &repositories.TaskMutation{Kind: repositories.TaskMutationTrash, Task: task}, nil
+	}
+
+	canUpdateTask, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateTask {
+		return nil, services.ErrNoPermission
+	}
+
+	var change models.TaskChange
+
+	switch operation.Action {
+	case services.BulkTaskActionReassign:
+		if s.formatUsers(task.Assignees) == s.formatUsers(operation.Assignees) {
+			return nil, nil
+		}
+
+		change = models.TaskChange{
+			Field:    "assignees",
+			OldValue: s.formatUsers(task.Assignees),
+			NewValue: s.formatUsers(operation.Assignees),
+		}
+		task.Assignees = operation.Assignees
+	case services.BulkTaskActionChangePriority:
+		if task.Priority == newPriority {
+			return nil, nil
+		}
+
+		change = models.TaskChange{
+			Field:    "priority",
+			OldValue: task.Priority.String(),
+			NewValue: newPriority.String(),
+		}
+		task.Priority = newPriority
+	default:
+		panic("invalid bulk task action")
+	}
+
+	return &repositories.TaskMutation{
+		Kind:    repositories.TaskMutationUpdate,
+		Task:    task,
+		Changes: []models.TaskChange{change},
+	}, nil
+}
+
+func (s *TaskService) isBulkItemError(err error) bool {
+	return errors.Is(err, services.ErrNoRecordsFound) ||
+		errors.Is(err, services.ErrEditConflict) ||
+		errors.Is(err, services.ErrNoPermission) ||
+		errors.Is(err, services.ErrTaskOverdue) ||
+		errors.Is(err, services.ErrTaskStatusConflict) ||
+		errors.Is(err, services.ErrTaskBlocked) ||
+		errors.Is(err, services.ErrTaskHasOpenSubtasks)
+}
+
+func (s *TaskService) ImportTasks(
+	ctx context.Context,
+	rows []*services.TaskImportRow,
+	isDryRun bool,
+	creator *models.User,
+	teamID int64,
+) ([]*services.TaskImportResult, *validator.Validator, error) {
+	canImportTasks, err := isMemberInRole(ctx, s.TeamRepo, teamID, creator.ID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canImportTasks {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	validator.Check(len(rows) > 0, taskImportRowsField, "Must contain at least one row.")
+	validator.Check(
+		len(rows) <= maxImportTasks,
+		taskImportRowsField,
+		fmt.Sprintf("Must not contain more than %d rows.", maxImportTasks),
+	)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	members, err := s.getImportAssignees(ctx, rows, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	results := make([]*services.TaskImportResult, 0, len(rows))
+	tasks := make([]*models.Task, 0, len(rows))
+	hasInvalidRows := false
+
+	for i, row := range rows {
+		task, rowValidator, err := s.newImportedTask(ctx, row, members, creator, teamID)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		result := &services.TaskImportResult{Row: i + 1}
+
+		if rowValidator.HasErrors() {
+			result.Errors = rowValidator.Errors
+			hasInvalidRows = true
+		} else {
+			result.Task = task
+			tasks = append(tasks, task)
+		}
+
+		results = append(results, result)
+	}
+
+	if hasInvalidRows || isDryRun {
+		return results, nil, nil
+	}
+
+	if err := s.TaskRepo.InsertMany(ctx, tasks, creator.ID, teamID); err != nil {
+		return nil, nil, err
+	}
+
+	return results, nil, nil
+}
+
+func (s *TaskService) getImportAssignees(
+	ctx context.Context,
+	rows []*services.TaskImportRow,
+	teamID int64,
+) (map[string]*models.User, error) {
+	var usernames []string
+
+	for _, row := range rows {
+		usernames = append(usernames, row.AssigneeUsernames...)
+	}
+
+	slices.Sort(usernames)
+	usernames = slices.Compact(usernames)
+
+	members := make(map[string]*models.User, len(usernames))
+
+	if len(usernames) == 0 {
+		return members, nil
+	}
+
+	users, err := s.TeamRepo.GetAllMembersByUsernames(ctx, usernames, teamID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, user := range users {
+		members[user.Username] = user
+	}
+
+	return members, nil
+}
+
+func (s *TaskService) newImportedTask(
+	ctx context.Context,
+	row *services.TaskImportRow,
+	members map[string]*models.User,
+	creator *models.User,
+	teamID int64,
+) (*models.Task, *validator.Validator, error) {
+	validator := validator.New()
+
+	var due time.Time
+
+	if row.Due == "" {
+		validator.AddError("due", "Must be provided.")
+	} else if parsed, err := time.Parse(time.RFC3339, row.Due); err != nil {
+		validator.AddError("due", "Must be a valid RFC 3339 timestamp.")
+	} else {
+		due = parsed
+	}
+
+	var estimate float64
+
+	if row.Estimate != "" {
+		parsed, err := strconv.ParseFloat(row.Estimate, 64)
+		if err != nil {
+			validator.AddError(taskEstimateField, "Must be a number.")
+		} else {
+			estimate = parsed
+		}
+	}
+
+	parentID := s.parseImportID(row.ParentID, taskParentIDField, validator)
+	milestoneID := s.parseImportID(row.MilestoneID, taskMilestoneIDField, validator)
+	projectID := s.parseImportID(row.ProjectID, taskProjectIDField, validator)
+
+	assignees := make([]*models.User, 0, len(row.AssigneeUsernames))
+
+	for _, username := range row.AssigneeUsernames {
+		member, ok := members[username]
+		if !ok {
+			validator.AddError(taskAssigneesField, fmt.Sprintf("The user %q is not a member of this team.", username))
+			continue
+		}
+
+		assignees = append(assignees, member)
+	}
+
+	task, err := s.newTask(
+		ctx,
+		due,
+		row.Title,
+		row.Description,
+		row.Priority,
+		creator,
+		assignees,
+		teamID,
+		parentID,
+		milestoneID,
+		projectID,
+		row.Labels,
+		estimate,
+		row.EstimateUnit,
+		validator,
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return task, validator, nil
+}
+
+func (s *TaskService) parseImportID(value, field string, validator *validator.Validator) *int64 {
+	if value == "" {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(value, 10, 64)
+	if err != nil || id < 1 {
+		validator.AddError(field, "Must be a positive integer.")
+		return nil
+	}
+
+	return &id
+}
+
+func (s *TaskService) GetVelocity(
+	ctx context.Context,
+	opts services.VelocityOptions,
+	teamID, retrieverID int64,
+) (*models.Velocity, *validator.Validator, error) {
+	validator := validator.New()
+
+	validator.Check(
+		opts.Period == velocityPeriodWeek || opts.Period == velocityPeriodIteration,
+		"period",
+		fmt.Sprintf("Must be %s or %s.", velocityPeriodWeek, velocityPeriodIteration),
+	)
+	validator.Check(
+		opts.Count >= 1 && opts.Count <= maxVelocityPeriods,
+		"count",
+		fmt.Sprintf("Must be between 1 and %d.", maxVelocityPeriods),
+	)
+
+	now := timefacade.Instance().Now()
+
+	if opts.Period == velocityPeriodIteration {
+		validator.Check(!opts.IterationStart.IsZero(), "iteration_start", "Must be provided.")
+		validator.Check(!opts.IterationStart.After(now), "iteration_start", "Must not be in the future.")
+		validator.Check(
+			opts.IterationLength >= 1 && opts.IterationLength <= maxIterationLengthDays,
+			"iteration_length",
+			fmt.Sprintf("Must be between 1 and %d days.", maxIterationLengthDays),
+		)
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canViewVelocity, err := isMemberInRole(ctx, s.TeamRepo, teamID, retrieverID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canViewVelocity {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	var currentStart time.Time
+
+	length := 7
+
+	switch opts.Period {
+	case velocityPeriodWeek:
+		today := now.UTC().Truncate(24 * time.Hour)
+		currentStart = today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	case velocityPeriodIteration:
+		length = opts.IterationLength
+		elapsed := int(now.Sub(opts.IterationStart) / (time.Duration(length) * 24 * time.Hour))
+		currentStart = opts.IterationStart.AddDate(0, 0, elapsed*length)
+	}
+
+	periods := make([]*models.VelocityPeriod, 0, opts.Count)
+
+	for i := opts.Count - 1; i >= 0; i-- {
+		start := currentStart.AddDate(0, 0, -i*length)
+		periods = append(periods, &models.VelocityPeriod{Start: start, End: start.AddDate(0, 0, length)})
+	}
+
+	completions, err := s.TaskRepo.GetCompletions(ctx, teamID, periods[0].Start, periods[len(periods)-1].End)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, completion := range completions {
+		for _, period := range periods {
+			if completion.CompletedAt.Before(period.Start) || !completion.CompletedAt.Before(period.End) {
+				continue
+			}
+
+			period.CompletedTasks++
+
+			if completion.Estimate != nil {
+				switch completion.Estimate.Unit {
+				case models.EstimateUnitPoints:
+					period.CompletedPoints += completion.Estimate.Value
+				case models.EstimateUnitHours:
+					period.CompletedHours += completion.Estimate.Value
+				}
+			}
+
+			break
+		}
+	}
+
+	velocity := &models.Velocity{Period: opts.Period, Iterations: periods}
+
+	finishedPeriods := periods
+	if len(periods) > 1 {
+		finishedPeriods = periods[:len(periods)-1]
+	}
+
+	for _, period := range finishedPeriods {
+		velocity.AveragePoints += period.CompletedPoints
+		velocity.AverageHours += period.CompletedHours
+	}
+
+	velocity.AveragePoints = math.Round(velocity.AveragePoints/float64(len(finishedPeriods))*100) / 100
+	velocity.AverageHours = math.Round(velocity.AverageHours/float64(len(finishedPeriods))*100) / 100
+
+	return velocity, nil, nil
+}
+
+func (s *TaskService) GetTrashedTaskByID(ctx context.Context, taskID, teamID int64) (*models.Task, error) {
+	return s.getTaskByID(ctx, taskID, teamID, true)
+}
+
+func (s *TaskService) TrashTask(ctx context.Context, task *models.Task, removerID int64) error {
+	canTrashTask, err := s.isCreatorOrMemberInRole(ctx, task, removerID, models.MemberRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !canTrashTask {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskRepo.Trash(ctx, task, removerID); err != nil {
+		return handleRepositoryUpdateError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskService) RestoreTask(ctx context.Context, task *models.Task, restorerID int64) error {
+	canRestoreTask, err := s.isCreatorOrMemberInRole(ctx, task, restorerID, models.MemberRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !canRestoreTask {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskRepo.Restore(ctx, task, restorerID); err != nil {
+		return handleRepositoryUpdateError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskService) PurgeTask(ctx context.Context, task *models.Task, removerID int64) error {
+	canPurgeTask, err := s.isMemberInRole(ctx, task, removerID, models.MemberRoleAdmin)
+	if err != nil {
+		return err
+	}
+
+	if !canPurgeTask {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskRepo.Purge(ctx, task.ID, task.TeamID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskService) PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.TaskRepo.PurgeTrashedBefore(ctx, timefacade.Instance().Now().Add(-retention))
+}
+
+func (s *TaskService) GetTaskHistory(
+	ctx context.Context,
+	taskID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskEvent, pagination.Metadata, error) {
+	return s.TaskRepo.GetAllEvents(ctx, taskID, paginationOpts)
+}
+
+func (s *TaskService) AddTaskBlocker(
+	ctx context.Context,
+	task *models.Task,
+	blockerID, updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateTask, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateTask {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if blockerID == task.ID {
+		validator.AddError(taskBlockerIDField, "A task cannot block itself.")
+		return validator, nil
+	}
+
+	if _, err := s.TaskRepo.GetByID(ctx, blockerID, task.TeamID, false); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			validator.AddError(taskBlockerIDField, "Must refer to an existing task in this team.")
+			return validator, nil
+		default:
+			return nil, err
+		}
+	}
+
+	createsCycle, err := s.TaskRepo.IsTransitivelyBlockedBy(ctx, blockerID, task.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if createsCycle {
+		validator.AddError(taskBlockerIDField, "Must not be a task that is already blocked by this task.")
+		return validator, nil
+	}
+
+	if err := s.TaskRepo.InsertDependency(ctx, task.ID, blockerID, updaterID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDependencyExists):
+			validator.AddError(taskBlockerIDField, "This task is already blocked by the given task.")
+			return validator, nil
+		default:
+			return nil, err
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *TaskService) RemoveTaskBlocker(ctx context.Context, task *models.Task, blockerID, updaterID int64) error {
+	canUpdateTask, err := s.isMemberInRole(ctx, task, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return err
+	}
+
+	if !canUpdateTask {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskRepo.DeleteDependency(ctx, task.ID, blockerID, updaterID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskService) WatchTask(ctx context.Context, task *models.Task, watcherID int64) error {
+	canWatchTask, err := s.isMemberInRole(ctx, task, watcherID, models.MemberRoleRegular)
+	if err != nil {
+		return err
+	}
+
+	if !canWatchTask {
+		return services.ErrNoPermission
+	}
+
+	return s.TaskRepo.InsertWatcher(ctx, task.ID, watcherID)
+}
+
+func (s *TaskService) UnwatchTask(ctx context.Context, task *models.Task, watcherID int64) error {
+	return s.TaskRepo.DeleteWatcher(ctx, task.ID, watcherID)
+}
+
+func (s *TaskService) getTaskByID(ctx context.Context, taskID, teamID int64, isTrashed bool) (*models.Task, error) {
+	task, err := s.TaskRepo.GetByID(ctx, taskID, teamID, isTrashed)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return task, nil
+}
+
+func (s *TaskService) getTeamByID(ctx context.Context, teamID int64) (*models.Team, error) {
+	team, err := s.TeamRepo.GetTeamByID(ctx, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return team, nil
+}
+
+func (s *TaskService) isCreatorOrMemberInRole(
+	ctx context.Context,
+	task *models.Task,
+	userID int64,
+	role models.MemberRole,
+) (bool, error) {
+	if task.Creator.ID == userID {
+		return true, nil
+	}
+
+	return s.isMemberInRole(ctx, task, userID, role)
+}
+
+func (s *TaskService) isMemberInRole(
+	ctx context.Context,
+	task *models.Task,
+	memberID int64,
+	role models.MemberRole,
+) (bool, error) {
+	return isMemberInRole(ctx, s.TeamRepo, task.TeamID, memberID, role)
+}
+
+func (s *TaskService) validateParent(
+	ctx context.Context,
+	task *models.Task,
+	parentID, teamID int64,
+	validator *validator.Validator,
+) error {
+	if _, err := s.TaskRepo.GetByID(ctx, parentID, teamID, false); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			validator.AddError(taskParentIDField, "Must refer to an existing task in this team.")
+			return nil
+		default:
+			return err
+		}
+	}
+
+	ancestorIDs, err := s.TaskRepo.GetAncestorIDs(ctx, parentID)
+	if err != nil {
+		return err
+	}
+
+	height := 0
+
+	if task != nil {
+		if slices.Contains(ancestorIDs, task.ID) {
+			validator.AddError(taskParentIDField, "Must not be the task itself or one of its subtasks.")
+			return nil
+		}
+
+		height, err = s.TaskRepo.GetSubtreeHeight(ctx, task.ID)
+		if err != nil {
+			return err
+		}
+	}
+
+	validator.Check(
+		len(ancestorIDs)+1+height <= maxTaskDepth,
+		taskParentIDField,
+		fmt.Sprintf("Subtasks must not be nested more than %d levels deep.", maxTaskDepth),
+	)
+
+	return nil
+}
+
+func (s *TaskService) validateMilestone(
+	ctx context.Context,
+	milestoneID, teamID int64,
+	validator *validator.Validator,
+) error {
+	milestone, err := s.MilestoneRepo.GetByID(ctx, milestoneID, teamID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			validator.AddError(taskMilestoneIDField, "Must refer to an existing milestone in this team.")
+			return nil
+		default:
+			return err
+		}
+	}
+
+	validator.Check(milestone.State == models.MilestoneStateOpen, taskMilestoneIDField, "Must refer to an open milestone.")
+
+	return nil
+}
+
+func (s *TaskService) validateProject(
+	ctx context.Context,
+	projectID, teamID int64,
+	validator *validator.Validator,
+) error {
+	project, err := s.ProjectRepo.GetByID(ctx, projectID, teamID)
+	if err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrNoRecordsFound):
+			validator.AddError(taskProjectIDField, "Must refer to an existing project in this team.")
+			return nil
+		default:
+			return err
+		}
+	}
+
+	validator.Check(!project.IsArchived, taskProjectIDField, "Must not refer to an archived project.")
+
+	return nil
+}
+
+func (s *TaskService) formatID(id *int64) string {
+	if id == nil {
+		return ""
+	}
+
+	return strconv.FormatInt(*id, 10)
+}
+
+func (s *TaskService) formatLabels(labels []*models.Label) string {
+	names := make([]string, 0, len(labels))
+	for _, label := range labels {
+		names = append(names, label.Name)
+	}
+
+	return strings.Join(names, ",")
+}
+
+func (s *TaskService) formatUsers(users []*models.User) string {
+	usernames := make([]string, 0, len(users))
+	for _, user := range users {
+		usernames = append(usernames, user.Username)
+	}
+
+	slices.Sort(usernames)
+
+	return strings.Join(usernames, ",")
+}
+
+func (s *TaskService) formatEstimate(estimate *models.TaskEstimate) string {
+	if estimate == nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(estimate.Value, 'f', -1, 64) + " " + estimate.Unit.String()
+}
+
+func (s *TaskService) validateDue(due time.Time, validator *validator.Validator) {
+	validator.Check(due.After(timefacade.Instance().Now()), "due", "Must be after the time of creation.")
+}
+
+func (s *TaskService) validateTitle(title string, validator *validator.Validator) {
+	validator.CheckNonZero(title, "title")
+}
+
+func (s *TaskService) validateDescription(description string, validator *validator.Validator) {
+	validator.CheckNonZero(description, "description")
+}
+
+func (s *TaskService) parseEstimate(
+	estimate float64,
+	unit string,
+	validator *validator.Validator,
+) *models.TaskEstimate {
+	if estimate == 0 {
+		return nil
+	}
+
+	validator.Check(estimate > 0, taskEstimateField, "Must be greater than zero.")
+	validator.Check(
+		estimate <= maxTaskEstimate,
+		taskEstimateField,
+		fmt.Sprintf("Must not be greater than %d.", maxTaskEstimate),
+	)
+
+	if unit == "" {
+		unit = models.EstimateUnitPoints.String()
+	}
+
+	estimateUnit, err := models.NewEstimateUnit(unit)
+	if err != nil {
+		validator.AddError(
+			taskEstimateUnitField,
+			fmt.Sprintf("Must be %s or %s.", models.EstimateUnitPoints, models.EstimateUnitHours),
+		)
+	}
+
+	return &models.TaskEstimate{Value: math.Round(estimate*100) / 100, Unit: estimateUnit}
+}
+
+func (s *TaskService) parsePriority(priority string, validator *validator.Validator) models.TaskPriority {
+	taskPriority, err := models.NewTaskPriority(priority)
+	if err != nil {
+		validator.AddError("priority", "Must be a valid task priority.")
+	}
+
+	return taskPriority
 }
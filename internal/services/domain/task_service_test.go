@@ -0,0 +1,270 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/services"
+)
+
+func TestUpdateTaskVersion(t *testing.T) {
+	const leaderID = 1
+
+	tests := []struct {
+		name        string
+		version     int
+		err         error
+		wantUpdated bool
+	}{
+		{name: "current version", version: 3, wantUpdated: true},
+		{name: "stale version", version: 2, err: services.ErrEditConflict},
+		{name: "future version", version: 4, err: services.ErrEditConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &fakeTaskRepo{}
+			service := &TaskService{
+				TaskRepo: taskRepo,
+				TeamRepo: &fakeTeamRepo{roles: map[int64]models.MemberRole{leaderID: models.MemberRoleLeader}},
+			}
+
+			task := &models.Task{ID: 10, Title: "Old title", TeamID: 20, Version: 3}
+			title := "New title"
+
+			validator, err := service.UpdateTask(
+				context.Background(),
+				services.TaskUpdate{Title: &title, Version: tt.version},
+				task,
+				leaderID,
+			)
+			if validator != nil {
+				t.Fatalf("UpdateTask() validation errors = %v", validator.Errors)
+			}
+
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("UpdateTask() error = %v, want %v", err, tt.err)
+			}
+
+			if got := len(taskRepo.updated) > 0; got != tt.wantUpdated {
+				t.Fatalf("UpdateTask() updated the task = %t, want %t", got, tt.wantUpdated)
+			}
+		})
+	}
+}
+
+func TestCheckStatusChangeBlockers(t *testing.T) {
+	const creatorID = 1
+
+	workflow := &models.Workflow{
+		Transitions: []*models.WorkflowTransition{
+			{From: "review", To: "in-progress", Actors: []models.TransitionActor{models.TransitionActorCreator}},
+		},
+	}
+
+	tests := []struct {
+		name            string
+		blockerCategory models.StatusCategory
+		err             error
+	}{
+		{name: "open blocker", blockerCategory: models.StatusCategoryTodo, err: services.ErrTaskBlocked},
+		{name: "started blocker", blockerCategory: models.StatusCategoryDoing, err: services.ErrTaskBlocked},
+		{name: "completed blocker", blockerCategory: models.StatusCategoryDone},
+		{name: "cancelled blocker", blockerCategory: models.StatusCategoryCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &TaskService{
+				TaskRepo: &fakeTaskRepo{
+					blockedBy: []*models.TaskRef{{ID: 11, Status: models.TaskStatus{Category: tt.blockerCategory}}},
+				},
+			}
+
+			task := &models.Task{
+				ID:      10,
+				Status:  models.TaskStatus{Name: "review", Category: models.StatusCategoryDoing},
+				Due:     time.Now().Add(24 * time.Hour),
+				Creator: &models.User{ID: creatorID},
+			}
+			newStatus := models.TaskStatus{Name: "in-progress", Category: models.StatusCategoryDoing}
+
+			err := service.checkStatusChange(
+				context.Background(),
+				task,
+				workflow,
+				models.OverduePolicyBlock,
+				newStatus,
+				creatorID,
+				false,
+			)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("checkStatusChange() error = %v, want %v", err, tt.err)
+			}
+		})
+	}
+}
+
+func TestBulkUpdateTasksVersions(t *testing.T) {
+	const (
+		leaderID = 1
+		teamID   = 20
+	)
+
+	tests := []struct {
+		name          string
+		isAtomic      bool
+		wantApplied   []bool
+		wantErrs      []error
+		wantMutations int
+	}{
+		{
+			name:          "best effort applies current versions",
+			wantApplied:   []bool{true, false, false},
+			wantErrs:      []error{nil, services.ErrEditConflict, services.ErrNoRecordsFound},
+			wantMutations: 1,
+		},
+		{
+			name:        "atomic applies nothing",
+			isAtomic:    true,
+			wantApplied: []bool{false, false, false},
+			wantErrs:    []error{nil, services.ErrEditConflict, services.ErrNoRecordsFound},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskRepo := &fakeTaskRepo{
+				tasks: map[int64]*models.Task{
+					10: {ID: 10, TeamID: teamID, Priority: models.TaskPriorityLow, Version: 3},
+					11: {ID: 11, TeamID: teamID, Priority: models.TaskPriorityLow, Version: 5},
+				},
+			}
+			service := &TaskService{
+				TaskRepo: taskRepo,
+				TeamRepo: &fakeTeamRepo{roles: map[int64]models.MemberRole{leaderID: models.MemberRoleLeader}},
+			}
+
+			items := []services.BulkTaskItem{
+				{TaskID: 10, Version: 3},
+				{TaskID: 11, Version: 4},
+				{TaskID: 12, Version: 1},
+			}
+			operation := services.BulkTaskOperation{
+				Action:   services.BulkTaskActionChangePriority,
+				Priority: "high",
+			}
+
+			results, validator, err := service.BulkUpdateTasks(
+				context.Background(),
+				items,
+				operation,
+				tt.isAtomic,
+				teamID,
+				leaderID,
+			)
+			if err != nil {
+				t.Fatalf("BulkUpdateTasks() error = %v", err)
+			}
+
+			if validator != nil {
+				t.Fatalf("BulkUpdateTasks() validation errors = %v", validator.Errors)
+			}
+
+			for i, result := range results {
+				if result.IsApplied != tt.wantApplied[i] {
+					t.Errorf("result %d applied = %t, want %t", i, result.IsApplied, tt.wantApplied[i])
+				}
+
+				if !errors.Is(result.Err, tt.wantErrs[i]) {
+					t.Errorf("result %d error = %v, want %v", i, result.Err, tt.wantErrs[i])
+				}
+			}
+
+			if len(taskRepo.mutations) != tt.wantMutations {
+				t.Fatalf("applied %d mutations, want %d", len(taskRepo.mutations), tt.wantMutations)
+			}
+		})
+	}
+}
+
+func TestGetBoardColumnMetadata(t *testing.T) {
+	const teamID = 20
+
+	todo := models.TaskStatus{Name: "open", Category: models.StatusCategoryTodo}
+	doing := models.TaskStatus{Name: "in-progress", Category: models.StatusCategoryDoing}
+
+	taskRepo := &fakeTaskRepo{
+		tasks: map[int64]*models.Task{
+			1: {ID: 1, TeamID: teamID, Status: todo},
+			2: {ID: 2, TeamID: teamID, Status: todo},
+			3: {ID: 3, TeamID: teamID, Status: todo},
+			4: {ID: 4, TeamID: teamID, Status: doing},
+		},
+		counts: map[string]int{"open": 3, "in-progress": 1},
+	}
+	service := &TaskService{
+		TaskRepo: taskRepo,
+		WorkflowRepo: &fakeWorkflowRepo{workflow: &models.Workflow{
+			Statuses: []*models.WorkflowStatus{
+				{Name: "open", Category: models.StatusCategoryTodo},
+				{Name: "in-progress", Category: models.StatusCategoryDoing},
+				{Name: "completed", Category: models.StatusCategoryDone},
+			},
+		}},
+	}
+
+	paginationOpts := pagination.NewOptions(2, 2, "rank", []string{"rank"})
+
+	columns, validator, err := service.GetBoard(
+		context.Background(),
+		models.TaskFilters{},
+		nil,
+		nil,
+		paginationOpts,
+		teamID,
+	)
+	if err != nil {
+		t.Fatalf("GetBoard() error = %v", err)
+	}
+
+	if validator != nil {
+		t.Fatalf("GetBoard() validation errors = %v", validator.Errors)
+	}
+
+	want := []struct {
+		status   string
+		tasks    int
+		metadata pagination.Metadata
+	}{
+		{status: "open", tasks: 1, metadata: pagination.Metadata{CurrentPage: 2, PageSize: 2, FirstPage: 1, LastPage: 2, TotalRecords: 3}},
+		{status: "in-progress", tasks: 0, metadata: pagination.Metadata{CurrentPage: 2, PageSize: 2, FirstPage: 1, LastPage: 1, TotalRecords: 1}},
+		{status: "completed", tasks: 0, metadata: pagination.Metadata{}},
+	}
+
+	if len(columns) != len(want) {
+		t.Fatalf("GetBoard() returned %d columns, want %d", len(columns), len(want))
+	}
+
+	for i, column := range columns {
+		if column.Status != want[i].status {
+			t.Errorf("column %d status = %q, want %q", i, column.Status, want[i].status)
+		}
+
+		if len(column.Tasks) != want[i].tasks {
+			t.Errorf("column %q has %d tasks, want %d", column.Status, len(column.Tasks), want[i].tasks)
+		}
+
+		if column.Count != want[i].metadata.TotalRecords {
+			t.Errorf("column %q count = %d, want %d", column.Status, column.Count, want[i].metadata.TotalRecords)
+		}
+
+		if column.Metadata != want[i].metadata {
+			t.Errorf("column %q metadata = %+v, want %+v", column.Status, column.Metadata, want[i].metadata)
+		}
+	}
+}
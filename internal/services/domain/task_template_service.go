@@ -0,0 +1,285 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/tasktemplate"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	taskTemplateNameField      = "name"
+	taskTemplateDueOffsetField = "due_offset"
+	taskTemplateValuesField    = "values"
+)
+
+type TaskTemplateService struct {
+	TaskTemplateRepo repositories.TaskTemplateRepository
+	TeamRepo         repositories.TeamRepository
+	LabelRepo        repositories.LabelRepository
+}
+
+func (s *TaskTemplateService) CreateTaskTemplate(
+	ctx context.Context,
+	name, title, description, priority, dueOffset string,
+	labels []string,
+	teamID, creatorID int64,
+) (*models.TaskTemplate, *validator.Validator, error) {
+	canCreateTemplate, err := isMemberInRole(ctx, s.TeamRepo, teamID, creatorID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateTemplate {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateName(name, validator)
+	validator.CheckNonZero(title, "title")
+	validator.CheckNonZero(description, "description")
+	s.validateDueOffset(dueOffset, validator)
+
+	templatePriority, err := models.NewTaskPriority(priority)
+	if err != nil {
+		validator.AddError("priority", "Must be a valid task priority.")
+	}
+
+	templateLabels, err := resolveLabels(ctx, s.LabelRepo, labels, teamID, validator)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	template := &models.TaskTemplate{
+		Name:        name,
+		Title:       title,
+		Description: description,
+		Priority:    templatePriority,
+		DueOffset:   dueOffset,
+		Labels:      labelNames(templateLabels),
+	}
+
+	if err := s.TaskTemplateRepo.Insert(ctx, template, teamID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateTemplateName):
+			s.addNameTakenError(validator)
+			return nil, validator, nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	s.setPlaceholders(template)
+
+	return template, nil, nil
+}
+
+func (s *TaskTemplateService) GetTaskTemplateByID(ctx context.Context, templateID, teamID int64) (*models.TaskTemplate, error) {
+	template, err := s.TaskTemplateRepo.GetByID(ctx, templateID, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	s.setPlaceholders(template)
+
+	return template, nil
+}
+
+func (s *TaskTemplateService) GetAllTaskTemplates(
+	ctx context.Context,
+	teamID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskTemplate, pagination.Metadata, error) {
+	templates, metadata, err := s.TaskTemplateRepo.GetAll(ctx, teamID, paginationOpts)
+	if err != nil {
+		return nil, pagination.Metadata{}, err
+	}
+
+	for _, template := range templates {
+		s.setPlaceholders(template)
+	}
+
+	return templates, metadata, nil
+}
+
+func (s *TaskTemplateService) UpdateTaskTemplate(
+	ctx context.Context,
+	update services.TaskTemplateUpdate,
+	template *models.TaskTemplate,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateTemplate, err := isMemberInRole(ctx, s.TeamRepo, template.TeamID, updaterID, models.MemberRoleLeader)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateTemplate {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if update.Name != nil {
+		s.validateName(*update.Name, validator)
+	}
+
+	if update.Title != nil {
+		validator.CheckNonZero(*update.Title, "title")
+	}
+
+	if update.Description != nil {
+		validator.CheckNonZero(*update.Description, "description")
+	}
+
+	if update.DueOffset != nil {
+		s.validateDueOffset(*update.DueOffset, validator)
+	}
+
+	var newPriority models.TaskPriority
+
+	if update.Priority != nil {
+		newPriority, err = models.NewTaskPriority(*update.Priority)
+		if err != nil {
+			validator.AddError("priority", "Must be a valid task priority.")
+		}
+	}
+
+	var newLabels []*models.Label
+
+	if update.Labels != nil {
+		newLabels, err = resolveLabels(ctx, s.LabelRepo, *update.Labels, template.TeamID, validator)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if update.Name != nil {
+		template.Name = *update.Name
+	}
+
+	if update.Title != nil {
+		template.Title = *update.Title
+	}
+
+	if update.Description != nil {
+		template.Description = *update.Description
+	}
+
+	if update.Priority != nil {
+		template.Priority = newPriority
+	}
+
+	if update.DueOffset != nil {
+		template.DueOffset = *update.DueOffset
+	}
+
+	if update.Labels != nil {
+		template.Labels = labelNames(newLabels)
+	}
+
+	if err := s.TaskTemplateRepo.Update(ctx, template); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateTemplateName):
+			s.addNameTakenError(validator)
+			return validator, nil
+		default:
+			return nil, handleRepositoryUpdateError(err)
+		}
+	}
+
+	s.setPlaceholders(template)
+
+	return nil, nil
+}
+
+func (s *TaskTemplateService) DeleteTaskTemplate(ctx context.Context, template *models.TaskTemplate, removerID int64) error {
+	canDeleteTemplate, err := isMemberInRole(ctx, s.TeamRepo, template.TeamID, removerID, models.MemberRoleLeader)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteTemplate {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskTemplateRepo.Delete(ctx, template.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskTemplateService) RenderTaskTemplate(
+	template *models.TaskTemplate,
+	values map[string]string,
+) (*services.TaskDraft, *validator.Validator, error) {
+	validator := validator.New()
+
+	for _, placeholder := range tasktemplate.Placeholders(template.Title, template.Description) {
+		if _, ok := values[placeholder]; !ok {
+			validator.AddError(taskTemplateValuesField, fmt.Sprintf("Must provide a value for the placeholder %q.", placeholder))
+			break
+		}
+	}
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	dueOffset, err := tasktemplate.ParseDueOffset(template.DueOffset)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	draft := &services.TaskDraft{
+		Due:         dueOffset.ApplyTo(timefacade.Instance().Now()),
+		Title:       tasktemplate.Render(template.Title, values),
+		Description: tasktemplate.Render(template.Description, values),
+		Priority:    template.Priority.String(),
+		Labels:      template.Labels,
+	}
+
+	return draft, nil, nil
+}
+
+func (s *TaskTemplateService) setPlaceholders(template *models.TaskTemplate) {
+	template.Placeholders = tasktemplate.Placeholders(template.Title, template.Description)
+
+	if template.Placeholders == nil {
+		template.Placeholders = []string{}
+	}
+}
+
+func (s *TaskTemplateService) validateName(name string, validator *validator.Validator) {
+	validator.CheckNonZero(name, taskTemplateNameField)
+	validator.CheckStringMaxLength(name, 64, taskTemplateNameField)
+}
+
+func (s *TaskTemplateService) validateDueOffset(dueOffset string, validator *validator.Validator) {
+	offset, err := tasktemplate.ParseDueOffset(dueOffset)
+	validator.Check(
+		err == nil && offset.IsPositive(),
+		taskTemplateDueOffsetField,
+		`Must be a positive offset such as "+3 days" or "+1 week 4 hours".`,
+	)
+}
+
+func (s *TaskTemplateService) addNameTakenError(validator *validator.Validator) {
+	validator.AddError(taskTemplateNameField, "A template with this name already exists in this team.")
+}
@@ -0,0 +1,183 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	taskViewNameField    = "name"
+	taskViewFiltersField = "filters"
+)
+
+type TaskViewService struct {
+	TaskViewRepo repositories.TaskViewRepository
+	TeamRepo     repositories.TeamRepository
+}
+
+func (s *TaskViewService) CreateTaskView(
+	ctx context.Context,
+	name string,
+	isShared bool,
+	filters models.TaskFilters,
+	sort string,
+	teamID, ownerID int64,
+) (*models.TaskView, *validator.Validator, error) {
+	canCreateView, err := isMemberInRole(ctx, s.TeamRepo, teamID, ownerID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canCreateView {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	s.validateName(name, validator)
+	s.validateFilters(filters, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	view := &models.TaskView{
+		Name:     name,
+		Owner:    &models.User{ID: ownerID},
+		IsShared: isShared,
+		Filters:  s.normalizeFilters(filters),
+		Sort:     sort,
+	}
+
+	if err := s.TaskViewRepo.Insert(ctx, view, teamID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateViewName):
+			s.addNameTakenError(validator)
+			return nil, validator, nil
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return view, nil, nil
+}
+
+func (s *TaskViewService) GetTaskViewByID(ctx context.Context, viewID, teamID, retrieverID int64) (*models.TaskView, error) {
+	view, err := s.TaskViewRepo.GetByID(ctx, viewID, teamID, retrieverID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return view, nil
+}
+
+func (s *TaskViewService) GetAllTaskViews(
+	ctx context.Context,
+	teamID, retrieverID int64,
+	paginationOpts pagination.Options,
+) ([]*models.TaskView, pagination.Metadata, error) {
+	return s.TaskViewRepo.GetAll(ctx, teamID, retrieverID, paginationOpts)
+}
+
+func (s *TaskViewService) UpdateTaskView(
+	ctx context.Context,
+	update services.TaskViewUpdate,
+	view *models.TaskView,
+	updaterID int64,
+) (*validator.Validator, error) {
+	if view.Owner.ID != updaterID {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if update.Name != nil {
+		s.validateName(*update.Name, validator)
+	}
+
+	if update.Filters != nil {
+		s.validateFilters(*update.Filters, validator)
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if update.Name != nil {
+		view.Name = *update.Name
+	}
+
+	if update.IsShared != nil {
+		view.IsShared = *update.IsShared
+	}
+
+	if update.Filters != nil {
+		view.Filters = s.normalizeFilters(*update.Filters)
+	}
+
+	if update.Sort != nil {
+		view.Sort = *update.Sort
+	}
+
+	if err := s.TaskViewRepo.Update(ctx, view); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrDuplicateViewName):
+			s.addNameTakenError(validator)
+			return validator, nil
+		default:
+			return nil, handleRepositoryUpdateError(err)
+		}
+	}
+
+	return nil, nil
+}
+
+func (s *TaskViewService) DeleteTaskView(ctx context.Context, view *models.TaskView, removerID int64) error {
+	canDeleteView := view.Owner.ID == removerID
+
+	if !canDeleteView && view.IsShared {
+		isAdmin, err := isMemberInRole(ctx, s.TeamRepo, view.TeamID, removerID, models.MemberRoleAdmin)
+		if err != nil {
+			return err
+		}
+
+		canDeleteView = isAdmin
+	}
+
+	if !canDeleteView {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TaskViewRepo.Delete(ctx, view.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *TaskViewService) validateName(name string, validator *validator.Validator) {
+	validator.CheckNonZero(name, taskViewNameField)
+	validator.CheckStringMaxLength(name, 64, taskViewNameField)
+}
+
+func (s *TaskViewService) validateFilters(filters models.TaskFilters, validator *validator.Validator) {
+	validator.CheckStringMaxLength(filters.Query, 256, taskViewFiltersField)
+}
+
+func (s *TaskViewService) normalizeFilters(filters models.TaskFilters) models.TaskFilters {
+	filters.ParentID = nil
+	filters.IsTrashed = false
+	filters.Labels = uniqueLabelNames(filters.Labels)
+
+	return filters
+}
+
+func (s *TaskViewService) addNameTakenError(validator *validator.Validator) {
+	validator.AddError(taskViewNameField, "You already have a view with this name in this team.")
+}
@@ -33,11 +33,12 @@ func (s *TeamService) CreateTeam(
 	}
 
 	team := &models.Team{
-		Name:     name,
-		IsPublic: isPublic,
+		Name:          name,
+		IsPublic:      isPublic,
+		OverduePolicy: models.OverduePolicyBlock,
 	}
 
-	if err := s.TeamRepo.InsertTeam(ctx, team, creatorID); err != nil {
+	if err := s.TeamRepo.InsertTeam(ctx, team, defaultWorkflow(), creatorID); err != nil {
 		switch {
 		case errors.Is(err, repositories.ErrDuplicateTeamName):
 			s.addTeamNameTakenError(validator)
@@ -67,6 +68,7 @@ func (s *TeamService) UpdateTeam(
 	ctx context.Context,
 	newName *string,
 	newIsPublic *bool,
+	newOverduePolicy *string,
 	team *models.Team,
 	updaterID int64,
 ) (*validator.Validator, error) {
@@ -103,6 +105,27 @@ func (s *TeamService) UpdateTeam(
 		}
 	}
 
+	if newOverduePolicy != nil {
+		overduePolicy, err := models.NewOverduePolicy(*newOverduePolicy)
+		if err != nil {
+			validator := validator.New()
+			validator.AddError(
+				"overdue_policy",
+				fmt.Sprintf(
+					"Must be one of %s, %s or %s.",
+					models.OverduePolicyBlock, models.OverduePolicyAllow, models.OverduePolicyFlag,
+				),
+			)
+
+			return validator, nil
+		}
+
+		if team.OverduePolicy != overduePolicy {
+			team.OverduePolicy = overduePolicy
+			isChanged = true
+		}
+	}
+
 	if !isChanged {
 		return nil, nil
 	}
@@ -310,17 +333,7 @@ func (s *TeamService) isMemberInRole(
 	teamID, memberID int64,
 	role models.MemberRole,
 ) (bool, error) {
-	memberRole, err := s.TeamRepo.GetMemberRole(ctx, teamID, memberID)
-	if err != nil {
-		switch {
-		case errors.Is(err, repositories.ErrNoRecordsFound):
-			return false, nil
-		default:
-			return false, err
-		}
-	}
-
-	return memberRole >= role, nil
+	return isMemberInRole(ctx, s.TeamRepo, teamID, memberID, role)
 }
 
 func (s *TeamService) getTeamByName(ctx context.Context, name string, retrieverID int64) (*models.Team, error) {
@@ -0,0 +1,324 @@
+package domain
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	timefacade "github.com/svetoslaven/tasktracker/internal/facades/time"
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/pagination"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	timeEntryStartedAtField   = "started_at"
+	timeEntryEndedAtField     = "ended_at"
+	timeEntryDescriptionField = "description"
+
+	maxTimeEntryDescriptionLength = 1000
+	maxTimeEntryDuration          = 24 * time.Hour
+	maxTimeReportRange            = 366 * 24 * time.Hour
+)
+
+type TimeEntryService struct {
+	TimeEntryRepo repositories.TimeEntryRepository
+	TeamRepo      repositories.TeamRepository
+}
+
+func (s *TimeEntryService) CreateTimeEntry(
+	ctx context.Context,
+	input services.TimeEntryInput,
+	task *models.Task,
+	user *models.User,
+) (*models.TimeEntry, *validator.Validator, error) {
+	validator := validator.New()
+
+	validator.CheckNonZero(input.StartedAt, timeEntryStartedAtField)
+	validator.CheckNonZero(input.EndedAt, timeEntryEndedAtField)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	startedAt := input.StartedAt.Truncate(time.Second)
+	endedAt := input.EndedAt.Truncate(time.Second)
+
+	s.validatePeriod(startedAt, &endedAt, validator)
+	s.validateDescription(input.Description, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canTrackTime, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, user.ID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canTrackTime {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	entry := &models.TimeEntry{
+		StartedAt:   startedAt,
+		EndedAt:     &endedAt,
+		Description: input.Description,
+		User:        user,
+	}
+
+	if err := s.TimeEntryRepo.Insert(ctx, entry, task.ID, user.ID); err != nil {
+		return nil, nil, err
+	}
+
+	return entry, nil, nil
+}
+
+func (s *TimeEntryService) StartTimer(
+	ctx context.Context,
+	description string,
+	task *models.Task,
+	user *models.User,
+) (*models.TimeEntry, *validator.Validator, error) {
+	validator := validator.New()
+
+	s.validateDescription(description, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canTrackTime, err := isMemberInRole(ctx, s.TeamRepo, task.TeamID, user.ID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canTrackTime {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	entry := &models.TimeEntry{
+		StartedAt:   timefacade.Instance().Now().Truncate(time.Second),
+		Description: description,
+		User:        user,
+	}
+
+	if err := s.TimeEntryRepo.Insert(ctx, entry, task.ID, user.ID); err != nil {
+		switch {
+		case errors.Is(err, repositories.ErrTimerAlreadyRunning):
+			return nil, nil, services.ErrTimerAlreadyRunning
+		default:
+			return nil, nil, err
+		}
+	}
+
+	return entry, nil, nil
+}
+
+func (s *TimeEntryService) StopTimer(ctx context.Context, userID int64) (*models.TimeEntry, error) {
+	entry, err := s.GetRunningTimeEntry(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	endedAt := timefacade.Instance().Now().Truncate(time.Second)
+	if endedAt.Before(entry.StartedAt) {
+		endedAt = entry.StartedAt
+	}
+
+	entry.EndedAt = &endedAt
+
+	if err := s.TimeEntryRepo.Update(ctx, entry); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return entry, nil
+}
+
+func (s *TimeEntryService) GetRunningTimeEntry(ctx context.Context, userID int64) (*models.TimeEntry, error) {
+	entry, err := s.TimeEntryRepo.GetRunning(ctx, userID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return entry, nil
+}
+
+func (s *TimeEntryService) GetTimeEntryByID(ctx context.Context, entryID, taskID int64) (*models.TimeEntry, error) {
+	entry, err := s.TimeEntryRepo.GetByID(ctx, entryID, taskID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return entry, nil
+}
+
+func (s *TimeEntryService) GetAllTimeEntries(
+	ctx context.Context,
+	paginationOpts pagination.Options,
+	taskID int64,
+) ([]*models.TimeEntry, pagination.Metadata, error) {
+	return s.TimeEntryRepo.GetAll(ctx, taskID, paginationOpts)
+}
+
+func (s *TimeEntryService) UpdateTimeEntry(
+	ctx context.Context,
+	update services.TimeEntryUpdate,
+	entry *models.TimeEntry,
+	task *models.Task,
+	updaterID int64,
+) (*validator.Validator, error) {
+	canUpdateEntry, err := s.isOwnerOrAdmin(ctx, entry, task, updaterID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !canUpdateEntry {
+		return nil, services.ErrNoPermission
+	}
+
+	validator := validator.New()
+
+	if update.StartedAt != nil {
+		validator.CheckNonZero(*update.StartedAt, timeEntryStartedAtField)
+		entry.StartedAt = update.StartedAt.Truncate(time.Second)
+	}
+
+	if update.EndedAt != nil {
+		validator.Check(entry.EndedAt != nil, timeEntryEndedAtField, "Must not be set while the timer is running.")
+		validator.CheckNonZero(*update.EndedAt, timeEntryEndedAtField)
+
+		endedAt := update.EndedAt.Truncate(time.Second)
+		entry.EndedAt = &endedAt
+	}
+
+	if update.Description != nil {
+		s.validateDescription(*update.Description, validator)
+		entry.Description = *update.Description
+	}
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	s.validatePeriod(entry.StartedAt, entry.EndedAt, validator)
+
+	if validator.HasErrors() {
+		return validator, nil
+	}
+
+	if err := s.TimeEntryRepo.Update(ctx, entry); err != nil {
+		return nil, handleRepositoryUpdateError(err)
+	}
+
+	return nil, nil
+}
+
+func (s *TimeEntryService) DeleteTimeEntry(
+	ctx context.Context,
+	entry *models.TimeEntry,
+	task *models.Task,
+	removerID int64,
+) error {
+	canDeleteEntry, err := s.isOwnerOrAdmin(ctx, entry, task, removerID)
+	if err != nil {
+		return err
+	}
+
+	if !canDeleteEntry {
+		return services.ErrNoPermission
+	}
+
+	if err := s.TimeEntryRepo.Delete(ctx, entry.ID); err != nil {
+		return handleRepositoryRetrievalError(err)
+	}
+
+	return nil
+}
+
+func (s *TimeEntryService) GetTimeReport(
+	ctx context.Context,
+	from, to time.Time,
+	groupBy string,
+	teamID, retrieverID int64,
+) (*models.TimeReport, *validator.Validator, error) {
+	validator := validator.New()
+
+	validator.Check(to.After(from), "to", "Must be after from.")
+	validator.Check(to.Sub(from) <= maxTimeReportRange, "to", "Must not be more than 366 days after from.")
+	validator.Check(
+		groupBy == "member" || groupBy == "task" || groupBy == "day",
+		"group_by",
+		"Must be one of member, task or day.",
+	)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	canViewReport, err := isMemberInRole(ctx, s.TeamRepo, teamID, retrieverID, models.MemberRoleRegular)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canViewReport {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	now := timefacade.Instance().Now()
+
+	rows, err := s.TimeEntryRepo.GetReport(ctx, teamID, from, to, now, groupBy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &models.TimeReport{
+		From:    from,
+		To:      to,
+		GroupBy: groupBy,
+		Rows:    rows,
+	}
+
+	for _, row := range rows {
+		report.TotalSeconds += row.TotalSeconds
+	}
+
+	return report, nil, nil
+}
+
+func (s *TimeEntryService) isOwnerOrAdmin(
+	ctx context.Context,
+	entry *models.TimeEntry,
+	task *models.Task,
+	userID int64,
+) (bool, error) {
+	if entry.User.ID == userID {
+		return true, nil
+	}
+
+	return isMemberInRole(ctx, s.TeamRepo, task.TeamID, userID, models.MemberRoleAdmin)
+}
+
+func (s *TimeEntryService) validatePeriod(startedAt time.Time, endedAt *time.Time, validator *validator.Validator) {
+	now := timefacade.Instance().Now()
+
+	validator.Check(!startedAt.After(now), timeEntryStartedAtField, "Must not be in the future.")
+
+	if endedAt == nil {
+		return
+	}
+
+	validator.Check(!endedAt.After(now), timeEntryEndedAtField, "Must not be in the future.")
+	validator.Check(endedAt.After(startedAt), timeEntryEndedAtField, "Must be after the start time.")
+	validator.Check(
+		endedAt.Sub(startedAt) <= maxTimeEntryDuration,
+		timeEntryEndedAtField,
+		"Must not be more than 24 hours after the start time.",
+	)
+}
+
+func (s *TimeEntryService) validateDescription(description string, validator *validator.Validator) {
+	validator.CheckStringMaxLength(description, maxTimeEntryDescriptionLength, timeEntryDescriptionField)
+}
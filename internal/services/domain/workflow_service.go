@@ -0,0 +1,347 @@
+package domain
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+	"github.com/svetoslaven/tasktracker/internal/repositories"
+	"github.com/svetoslaven/tasktracker/internal/services"
+	"github.com/svetoslaven/tasktracker/internal/validator"
+)
+
+const (
+	workflowStatusesField      = "statuses"
+	workflowTransitionsField   = "transitions"
+	workflowStatusMappingField = "status_mapping"
+
+	maxWorkflowStatuses       = 20
+	maxWorkflowTransitions    = 100
+	maxWorkflowStatusNameSize = 32
+)
+
+type WorkflowService struct {
+	WorkflowRepo repositories.WorkflowRepository
+	TeamRepo     repositories.TeamRepository
+}
+
+func (s *WorkflowService) GetWorkflow(ctx context.Context, teamID int64) (*models.Workflow, error) {
+	return getWorkflow(ctx, s.WorkflowRepo, teamID)
+}
+
+func (s *WorkflowService) UpdateWorkflow(
+	ctx context.Context,
+	input services.WorkflowInput,
+	teamID, updaterID int64,
+) (*models.Workflow, *validator.Validator, error) {
+	canUpdateWorkflow, err := isMemberInRole(ctx, s.TeamRepo, teamID, updaterID, models.MemberRoleOwner)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !canUpdateWorkflow {
+		return nil, nil, services.ErrNoPermission
+	}
+
+	current, err := getWorkflow(ctx, s.WorkflowRepo, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	validator := validator.New()
+
+	workflow := &models.Workflow{TeamID: teamID}
+	workflow.Statuses = s.parseStatuses(input.Statuses, current, validator)
+	workflow.Transitions = s.parseTransitions(input.Transitions, workflow, validator)
+	statusMapping := s.parseStatusMapping(input.StatusMapping, current, workflow, validator)
+
+	if validator.HasErrors() {
+		return nil, validator, nil
+	}
+
+	if err := s.WorkflowRepo.Replace(ctx, workflow, statusMapping); err != nil {
+		return nil, nil, handleRepositoryUpdateError(err)
+	}
+
+	workflow, err = getWorkflow(ctx, s.WorkflowRepo, teamID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return workflow, nil, nil
+}
+
+func (s *WorkflowService) parseStatuses(
+	inputs []services.WorkflowStatusInput,
+	current *models.Workflow,
+	validator *validator.Validator,
+) []*models.WorkflowStatus {
+	validator.Check(len(inputs) > 0, workflowStatusesField, "Must contain at least one status.")
+	validator.Check(
+		len(inputs) <= maxWorkflowStatuses,
+		workflowStatusesField,
+		fmt.Sprintf("Must not contain more than %d statuses.", maxWorkflowStatuses),
+	)
+
+	statuses := make([]*models.WorkflowStatus, 0, len(inputs))
+	initialCount := 0
+
+	for _, input := range inputs {
+		validator.Check(input.Name != "", workflowStatusesField, "Every status must have a name.")
+		validator.Check(
+			len(input.Name) <= maxWorkflowStatusNameSize,
+			workflowStatusesField,
+			fmt.Sprintf("Status names must be no more than %d bytes long.", maxWorkflowStatusNameSize),
+		)
+		validator.Check(
+			!strings.Contains(input.Name, ","),
+			workflowStatusesField,
+			"Status names must not contain commas.",
+		)
+		validator.Check(
+			findWorkflowStatus(&models.Workflow{Statuses: statuses}, input.Name) == nil,
+			workflowStatusesField,
+			fmt.Sprintf("Contains the status %q more than once.", input.Name),
+		)
+
+		category, err := models.NewStatusCategory(input.Category)
+		validator.Check(
+			err == nil,
+			workflowStatusesField,
+			fmt.Sprintf(
+				"The category of status %q must be one of %s, %s, %s or %s.",
+				input.Name,
+				models.StatusCategoryTodo, models.StatusCategoryDoing,
+				models.StatusCategoryDone, models.StatusCategoryCancelled,
+			),
+		)
+
+		if input.IsInitial {
+			initialCount++
+
+			validator.Check(
+				category == models.StatusCategoryTodo,
+				workflowStatusesField,
+				fmt.Sprintf("The initial status %q must be in the %s category.", input.Name, models.StatusCategoryTodo),
+			)
+		}
+
+		status := &models.WorkflowStatus{Name: input.Name, Category: category, IsInitial: input.IsInitial}
+
+		if currentStatus := findWorkflowStatus(current, input.Name); currentStatus != nil {
+			status.ID = currentStatus.ID
+		}
+
+		statuses = append(statuses, status)
+	}
+
+	validator.Check(initialCount == 1, workflowStatusesField, "Must contain exactly one initial status.")
+
+	return statuses
+}
+
+func (s *WorkflowService) parseTransitions(
+	inputs []services.WorkflowTransitionInput,
+	workflow *models.Workflow,
+	validator *validator.Validator,
+) []*models.WorkflowTransition {
+	validator.Check(
+		len(inputs) <= maxWorkflowTransitions,
+		workflowTransitionsField,
+		fmt.Sprintf("Must not contain more than %d transitions.", maxWorkflowTransitions),
+	)
+
+	transitions := make([]*models.WorkflowTransition, 0, len(inputs))
+
+	for _, input := range inputs {
+		validator.Check(
+			findWorkflowStatus(workflow, input.From) != nil,
+			workflowTransitionsField,
+			fmt.Sprintf("Refers to an unknown status %q.", input.From),
+		)
+		validator.Check(
+			findWorkflowStatus(workflow, input.To) != nil,
+			workflowTransitionsField,
+			fmt.Sprintf("Refers to an unknown status %q.", input.To),
+		)
+		validator.Check(
+			input.From != input.To,
+			workflowTransitionsField,
+			fmt.Sprintf("The transition from %q must lead to a different status.", input.From),
+		)
+		validator.Check(
+			findWorkflowTransition(&models.Workflow{Transitions: transitions}, input.From, input.To) == nil,
+			workflowTransitionsField,
+			fmt.Sprintf("Contains the transition from %q to %q more than once.", input.From, input.To),
+		)
+		validator.Check(
+			len(input.Actors) > 0,
+			workflowTransitionsField,
+			fmt.Sprintf("The transition from %q to %q must allow at least one actor.", input.From, input.To),
+		)
+
+		transition := &models.WorkflowTransition{From: input.From, To: input.To}
+
+		for _, a := range input.Actors {
+			actor, err := models.NewTransitionActor(a)
+			if err != nil {
+				validator.AddError(
+					workflowTransitionsField,
+					fmt.Sprintf(
+						"Contains an invalid actor %q. Must be one of %s, %s or %s.",
+						a, models.TransitionActorCreator, models.TransitionActorAssignee, models.TransitionActorLeader,
+					),
+				)
+				continue
+			}
+
+			if !slices.Contains(transition.Actors, actor) {
+				transition.Actors = append(transition.Actors, actor)
+			}
+		}
+
+		transitions = append(transitions, transition)
+	}
+
+	return transitions
+}
+
+func (s *WorkflowService) parseStatusMapping(
+	mapping map[string]string,
+	current, workflow *models.Workflow,
+	validator *validator.Validator,
+) map[int64]*models.WorkflowStatus {
+	oldNames := make([]string, 0, len(mapping))
+	for oldName := range mapping {
+		oldNames = append(oldNames, oldName)
+	}
+
+	sort.Strings(oldNames)
+
+	statusMapping := make(map[int64]*models.WorkflowStatus, len(mapping))
+
+	for _, oldName := range oldNames {
+		oldStatus := findWorkflowStatus(current, oldName)
+		if oldStatus == nil {
+			validator.AddError(workflowStatusMappingField, fmt.Sprintf("Refers to an unknown current status %q.", oldName))
+			continue
+		}
+
+		if findWorkflowStatus(workflow, oldName) != nil {
+			validator.AddError(
+				workflowStatusMappingField,
+				fmt.Sprintf("The status %q is kept in the workflow and must not be mapped.", oldName),
+			)
+			continue
+		}
+
+		newStatus := findWorkflowStatus(workflow, mapping[oldName])
+		if newStatus == nil {
+			validator.AddError(
+				workflowStatusMappingField,
+				fmt.Sprintf("Maps %q to %q, which is not a status in the new workflow.", oldName, mapping[oldName]),
+			)
+			continue
+		}
+
+		statusMapping[oldStatus.ID] = newStatus
+	}
+
+	for _, status := range current.Statuses {
+		if findWorkflowStatus(workflow, status.Name) != nil {
+			continue
+		}
+
+		_, isMapped := statusMapping[status.ID]
+
+		validator.Check(
+			status.TaskCount == 0 || isMapped,
+			workflowStatusMappingField,
+			fmt.Sprintf(
+				"The removed status %q is used by %d tasks and must be mapped to a status in the new workflow.",
+				status.Name, status.TaskCount,
+			),
+		)
+	}
+
+	return statusMapping
+}
+
+func defaultWorkflow() *models.Workflow {
+	creatorOnly := []models.TransitionActor{models.TransitionActorCreator}
+
+	return &models.Workflow{
+		Statuses: []*models.WorkflowStatus{
+			{Name: "open", Category: models.StatusCategoryTodo, IsInitial: true},
+			{Name: "in-progress", Category: models.StatusCategoryDoing},
+			{Name: "completed", Category: models.StatusCategoryDone},
+			{Name: "cancelled", Category: models.StatusCategoryCancelled},
+		},
+		Transitions: []*models.WorkflowTransition{
+			{From: "open", To: "in-progress", Actors: []models.TransitionActor{models.TransitionActorAssignee}},
+			{From: "in-progress", To: "completed", Actors: creatorOnly},
+			{From: "open", To: "cancelled", Actors: creatorOnly},
+			{From: "in-progress", To: "cancelled", Actors: creatorOnly},
+		},
+	}
+}
+
+func getWorkflow(
+	ctx context.Context,
+	workflowRepo repositories.WorkflowRepository,
+	teamID int64,
+) (*models.Workflow, error) {
+	workflow, err := workflowRepo.GetByTeamID(ctx, teamID)
+	if err != nil {
+		return nil, handleRepositoryRetrievalError(err)
+	}
+
+	return workflow, nil
+}
+
+func findWorkflowStatus(workflow *models.Workflow, name string) *models.WorkflowStatus {
+	for _, status := range workflow.Statuses {
+		if status.Name == name {
+			return status
+		}
+	}
+
+	return nil
+}
+
+func findWorkflowStatusInCategory(
+	workflow *models.Workflow,
+	from string,
+	category models.StatusCategory,
+) *models.WorkflowStatus {
+	var first *models.WorkflowStatus
+
+	for _, status := range workflow.Statuses {
+		if status.Category != category {
+			continue
+		}
+
+		if findWorkflowTransition(workflow, from, status.Name) != nil {
+			return status
+		}
+
+		if first == nil {
+			first = status
+		}
+	}
+
+	return first
+}
+
+func findWorkflowTransition(workflow *models.Workflow, from, to string) *models.WorkflowTransition {
+	for _, transition := range workflow.Transitions {
+		if transition.From == from && transition.To == to {
+			return transition
+		}
+	}
+
+	return nil
+}
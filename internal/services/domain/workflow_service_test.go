@@ -0,0 +1,52 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/svetoslaven/tasktracker/internal/models"
+)
+
+func TestFindWorkflowStatusInCategory(t *testing.T) {
+	workflow := &models.Workflow{
+		Statuses: []*models.WorkflowStatus{
+			{Name: "backlog", Category: models.StatusCategoryTodo},
+			{Name: "doing", Category: models.StatusCategoryDoing},
+			{Name: "review", Category: models.StatusCategoryDoing},
+			{Name: "shipped", Category: models.StatusCategoryDone},
+			{Name: "verified", Category: models.StatusCategoryDone},
+		},
+		Transitions: []*models.WorkflowTransition{
+			{From: "backlog", To: "doing"},
+			{From: "doing", To: "review"},
+			{From: "review", To: "verified"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		from     string
+		category models.StatusCategory
+		want     string
+	}{
+		{name: "first status in category", from: "backlog", category: models.StatusCategoryDoing, want: "doing"},
+		{name: "reachable status first", from: "review", category: models.StatusCategoryDone, want: "verified"},
+		{name: "first status when none is reachable", from: "backlog", category: models.StatusCategoryDone, want: "shipped"},
+		{name: "skips the current status", from: "doing", category: models.StatusCategoryDoing, want: "review"},
+		{name: "no status in category", from: "backlog", category: models.StatusCategoryCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			status := findWorkflowStatusInCategory(workflow, tt.from, tt.category)
+
+			var got string
+			if status != nil {
+				got = status.Name
+			}
+
+			if got != tt.want {
+				t.Fatalf("findWorkflowStatusInCategory(%q, %s) = %q, want %q", tt.from, tt.category, got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"errors"
+	"io"
 	"time"
 
 	"github.com/svetoslaven/tasktracker/internal/models"
@@ -21,8 +22,20 @@ var (
 	ErrCannotRemoveTeamOwner = errors.New("services: cannot remove team owner")
 	ErrCannotChangeOwnerRole = errors.New("services: cannot change owner role")
 
-	ErrTaskOverdue        = errors.New("services: task overdue")
-	ErrTaskStatusConflict = errors.New("services: task status conflict")
+	ErrTaskOverdue         = errors.New("services: task overdue")
+	ErrTaskStatusConflict  = errors.New("services: task status conflict")
+	ErrTaskHasOpenSubtasks = errors.New("services: task has open subtasks")
+	ErrTaskBlocked         = errors.New("services: task blocked")
+
+	ErrTimerAlreadyRunning = errors.New("services: timer already running")
+
+	ErrPendingExtensionExists  = errors.New("services: pending extension already exists")
+	ErrExtensionAlreadyDecided = errors.New("services: extension already decided")
+
+	ErrMilestoneClosed = errors.New("services: milestone closed")
+
+	ErrCreatorNotLeader = errors.New("services: recurring task creator is not a leader")
+	ErrNoActiveAssignee = errors.New("services: no recurring task assignee is a team member")
 )
 
 type UserService interface {
@@ -44,7 +57,7 @@ type TeamService interface {
 	CreateTeam(ctx context.Context, name string, isPublic bool, creatorID int64) (*models.Team, *validator.Validator, error)
 	GetTeamByName(ctx context.Context, name string, retrieverID int64) (*models.Team, error)
 	GetAllTeams(ctx context.Context, filters models.TeamFilters, paginationOpts pagination.Options, retrieverID int64) ([]*models.Team, pagination.Metadata, error)
-	UpdateTeam(ctx context.Context, newName *string, newIsPublic *bool, team *models.Team, updaterID int64) (*validator.Validator, error)
+	UpdateTeam(ctx context.Context, newName *string, newIsPublic *bool, newOverduePolicy *string, team *models.Team, updaterID int64) (*validator.Validator, error)
 	DeleteTeam(ctx context.Context, teamID, removerID int64) error
 
 	IsMember(ctx context.Context, teamID, userID int64) (bool, error)
@@ -59,16 +72,326 @@ type TeamService interface {
 	RemoveMemberFromTeam(ctx context.Context, teamID, memberID, removerID int64) error
 }
 
+type WorkflowStatusInput struct {
+	Name      string
+	Category  string
+	IsInitial bool
+}
+
+type WorkflowTransitionInput struct {
+	From   string
+	To     string
+	Actors []string
+}
+
+type WorkflowInput struct {
+	Statuses      []WorkflowStatusInput
+	Transitions   []WorkflowTransitionInput
+	StatusMapping map[string]string
+}
+
+type WorkflowService interface {
+	GetWorkflow(ctx context.Context, teamID int64) (*models.Workflow, error)
+	UpdateWorkflow(ctx context.Context, input WorkflowInput, teamID, updaterID int64) (*models.Workflow, *validator.Validator, error)
+}
+
+type TaskUpdate struct {
+	Due          *time.Time
+	Title        *string
+	Description  *string
+	Priority     *string
+	Assignees    *[]*models.User
+	ParentID     *int64
+	MilestoneID  *int64
+	ProjectID    *int64
+	Labels       *[]string
+	Estimate     *float64
+	EstimateUnit *string
+	Version      int
+}
+
+type TaskMove struct {
+	Status       string
+	AfterTaskID  *int64
+	BeforeTaskID *int64
+	Force        bool
+}
+
+type VelocityOptions struct {
+	Period          string
+	Count           int
+	IterationStart  time.Time
+	IterationLength int
+}
+
+type BulkTaskAction int
+
+const (
+	BulkTaskActionChangeStatus BulkTaskAction = iota + 1
+	BulkTaskActionReassign
+	BulkTaskActionChangePriority
+	BulkTaskActionDelete
+)
+
+type BulkTaskOperation struct {
+	Action    BulkTaskAction
+	Status    string
+	Priority  string
+	Assignees []*models.User
+	Force     bool
+}
+
+type BulkTaskItem struct {
+	TaskID  int64
+	Version int
+}
+
+type BulkTaskResult struct {
+	TaskID    int64
+	Task      *models.Task
+	OldStatus string
+	IsApplied bool
+	Err       error
+}
+
+type TaskImportRow struct {
+	Due               string
+	Title             string
+	Description       string
+	Priority          string
+	AssigneeUsernames []string
+	Labels            []string
+	Estimate          string
+	EstimateUnit      string
+	ParentID          string
+	MilestoneID       string
+	ProjectID         string
+}
+
+type TaskImportResult struct {
+	Row    int
+	Task   *models.Task
+	Errors map[string]string
+}
+
 type TaskService interface {
-	CreateTask(ctx context.Context, due time.Time, title, description string, priority string, creator, assignee *models.User, teamID int64) (*models.Task, *validator.Validator, error)
+	CreateTask(ctx context.Context, due time.Time, title, description string, priority string, creator *models.User, assignees []*models.User, teamID int64, parentID, milestoneID, projectID *int64, labels []string, estimate float64, estimateUnit string) (*models.Task, *validator.Validator, error)
 	GetTaskByID(ctx context.Context, taskID, teamID int64) (*models.Task, error)
 	GetAllTasks(ctx context.Context, filters models.TaskFilters, status, priority []string, paginationOpts pagination.Options, teamID int64) ([]*models.Task, pagination.Metadata, *validator.Validator, error)
-	UpdateTaskStatus(ctx context.Context, task *models.Task, newStatus models.TaskStatus, updaterID int64) error
+	UpdateTaskStatus(ctx context.Context, task *models.Task, newStatus string, updaterID int64, force bool) (*validator.Validator, error)
+	UpdateTaskStatusCategory(ctx context.Context, task *models.Task, category models.StatusCategory, updaterID int64, force bool) (*validator.Validator, error)
+	MoveTask(ctx context.Context, task *models.Task, move TaskMove, updaterID int64) (*validator.Validator, error)
+	GetBoard(ctx context.Context, filters models.TaskFilters, status, priority []string, paginationOpts pagination.Options, teamID int64) ([]*models.BoardColumn, *validator.Validator, error)
+	UpdateTask(ctx context.Context, update TaskUpdate, task *models.Task, updaterID int64) (*validator.Validator, error)
+	BulkUpdateTasks(ctx context.Context, items []BulkTaskItem, operation BulkTaskOperation, isAtomic bool, teamID, updaterID int64) ([]*BulkTaskResult, *validator.Validator, error)
+	ImportTasks(ctx context.Context, rows []*TaskImportRow, isDryRun bool, creator *models.User, teamID int64) ([]*TaskImportResult, *validator.Validator, error)
+	GetVelocity(ctx context.Context, opts VelocityOptions, teamID, retrieverID int64) (*models.Velocity, *validator.Validator, error)
+
+	GetTrashedTaskByID(ctx context.Context, taskID, teamID int64) (*models.Task, error)
+	TrashTask(ctx context.Context, task *models.Task, removerID int64) error
+	RestoreTask(ctx context.Context, task *models.Task, restorerID int64) error
+	PurgeTask(ctx context.Context, task *models.Task, removerID int64) error
+	PurgeExpiredTasks(ctx context.Context, retention time.Duration) (int64, error)
+
+	GetTaskHistory(ctx context.Context, taskID int64, paginationOpts pagination.Options) ([]*models.TaskEvent, pagination.Metadata, error)
+
+	AddTaskBlocker(ctx context.Context, task *models.Task, blockerID, updaterID int64) (*validator.Validator, error)
+	RemoveTaskBlocker(ctx context.Context, task *models.Task, blockerID, updaterID int64) error
+
+	WatchTask(ctx context.Context, task *models.Task, watcherID int64) error
+	UnwatchTask(ctx context.Context, task *models.Task, watcherID int64) error
+}
+
+type CommentService interface {
+	CreateComment(ctx context.Context, body string, parentID *int64, author *models.User, task *models.Task) (*models.Comment, *validator.Validator, error)
+	GetCommentByID(ctx context.Context, commentID, taskID int64) (*models.Comment, error)
+	GetAllComments(ctx context.Context, filters models.CommentFilters, paginationOpts pagination.Options, taskID int64) ([]*models.Comment, pagination.Metadata, error)
+	UpdateComment(ctx context.Context, newBody string, comment *models.Comment, task *models.Task, updaterID int64) (*validator.Validator, error)
+	DeleteComment(ctx context.Context, comment *models.Comment, task *models.Task, removerID int64) error
+}
+
+type AttachmentUpload struct {
+	Filename string
+	Content  io.Reader
+}
+
+type AttachmentService interface {
+	CreateAttachment(ctx context.Context, upload AttachmentUpload, task *models.Task, uploader *models.User) (*models.Attachment, *validator.Validator, error)
+	GetAttachmentByID(ctx context.Context, attachmentID, taskID int64) (*models.Attachment, error)
+	GetAllAttachments(ctx context.Context, paginationOpts pagination.Options, taskID int64) ([]*models.Attachment, pagination.Metadata, error)
+	OpenAttachment(ctx context.Context, attachment *models.Attachment) (io.ReadSeekCloser, error)
+	DeleteAttachment(ctx context.Context, attachment *models.Attachment, task *models.Task, removerID int64) error
+	DeleteOrphanedBlobs(ctx context.Context) (int, error)
+}
+
+type TimeEntryInput struct {
+	StartedAt   time.Time
+	EndedAt     time.Time
+	Description string
+}
+
+type TimeEntryUpdate struct {
+	StartedAt   *time.Time
+	EndedAt     *time.Time
+	Description *string
+}
+
+type DueDateExtensionService interface {
+	RequestExtension(ctx context.Context, proposedDue time.Time, reason string, task *models.Task, requester *models.User) (*models.DueDateExtension, *validator.Validator, error)
+	GetExtensionByID(ctx context.Context, extensionID, taskID int64) (*models.DueDateExtension, error)
+	GetAllExtensions(ctx context.Context, filters models.DueDateExtensionFilters, paginationOpts pagination.Options, taskID int64) ([]*models.DueDateExtension, pagination.Metadata, error)
+	ApproveExtension(ctx context.Context, note string, extension *models.DueDateExtension, task *models.Task, decider *models.User) (*validator.Validator, error)
+	RejectExtension(ctx context.Context, note string, extension *models.DueDateExtension, task *models.Task, decider *models.User) (*validator.Validator, error)
+}
+
+type TimeEntryService interface {
+	CreateTimeEntry(ctx context.Context, input TimeEntryInput, task *models.Task, user *models.User) (*models.TimeEntry, *validator.Validator, error)
+	StartTimer(ctx context.Context, description string, task *models.Task, user *models.User) (*models.TimeEntry, *validator.Validator, error)
+	StopTimer(ctx context.Context, userID int64) (*models.TimeEntry, error)
+	GetRunningTimeEntry(ctx context.Context, userID int64) (*models.TimeEntry, error)
+	GetTimeEntryByID(ctx context.Context, entryID, taskID int64) (*models.TimeEntry, error)
+	GetAllTimeEntries(ctx context.Context, paginationOpts pagination.Options, taskID int64) ([]*models.TimeEntry, pagination.Metadata, error)
+	UpdateTimeEntry(ctx context.Context, update TimeEntryUpdate, entry *models.TimeEntry, task *models.Task, updaterID int64) (*validator.Validator, error)
+	DeleteTimeEntry(ctx context.Context, entry *models.TimeEntry, task *models.Task, removerID int64) error
+	GetTimeReport(ctx context.Context, from, to time.Time, groupBy string, teamID, retrieverID int64) (*models.TimeReport, *validator.Validator, error)
+}
+
+type LabelService interface {
+	CreateLabel(ctx context.Context, name, color string, teamID, creatorID int64) (*models.Label, *validator.Validator, error)
+	GetLabelByID(ctx context.Context, labelID, teamID int64) (*models.Label, error)
+	GetAllLabels(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.Label, pagination.Metadata, error)
+	UpdateLabel(ctx context.Context, newName, newColor *string, label *models.Label, updaterID int64) (*validator.Validator, error)
+	DeleteLabel(ctx context.Context, label *models.Label, removerID int64) error
+}
+
+type MilestoneUpdate struct {
+	Name     *string
+	StartsAt *time.Time
+	EndsAt   *time.Time
+}
+
+type MilestoneService interface {
+	CreateMilestone(ctx context.Context, name string, startsAt, endsAt time.Time, teamID, creatorID int64) (*models.Milestone, *validator.Validator, error)
+	GetMilestoneByID(ctx context.Context, milestoneID, teamID int64) (*models.Milestone, error)
+	GetAllMilestones(ctx context.Context, states []string, paginationOpts pagination.Options, teamID int64) ([]*models.Milestone, pagination.Metadata, *validator.Validator, error)
+	UpdateMilestone(ctx context.Context, update MilestoneUpdate, milestone *models.Milestone, updaterID int64) (*validator.Validator, error)
+	CloseMilestone(ctx context.Context, milestone *models.Milestone, nextMilestoneID *int64, closerID int64) (int, *validator.Validator, error)
+	DeleteMilestone(ctx context.Context, milestone *models.Milestone, removerID int64) error
+}
+
+type ProjectUpdate struct {
+	Name         *string
+	Description  *string
+	LeadUsername *string
+	IsArchived   *bool
+}
+
+type ProjectService interface {
+	CreateProject(ctx context.Context, name, description, leadUsername string, teamID, creatorID int64) (*models.Project, *validator.Validator, error)
+	GetProjectByID(ctx context.Context, projectID, teamID int64) (*models.Project, error)
+	GetAllProjects(ctx context.Context, filters models.ProjectFilters, paginationOpts pagination.Options, teamID int64) ([]*models.Project, pagination.Metadata, error)
+	UpdateProject(ctx context.Context, update ProjectUpdate, project *models.Project, updaterID int64) (*validator.Validator, error)
+	DeleteProject(ctx context.Context, project *models.Project, removerID int64) error
+}
+
+type TaskViewUpdate struct {
+	Name     *string
+	IsShared *bool
+	Filters  *models.TaskFilters
+	Sort     *string
+}
+
+type TaskViewService interface {
+	CreateTaskView(ctx context.Context, name string, isShared bool, filters models.TaskFilters, sort string, teamID, ownerID int64) (*models.TaskView, *validator.Validator, error)
+	GetTaskViewByID(ctx context.Context, viewID, teamID, retrieverID int64) (*models.TaskView, error)
+	GetAllTaskViews(ctx context.Context, teamID, retrieverID int64, paginationOpts pagination.Options) ([]*models.TaskView, pagination.Metadata, error)
+	UpdateTaskView(ctx context.Context, update TaskViewUpdate, view *models.TaskView, updaterID int64) (*validator.Validator, error)
+	DeleteTaskView(ctx context.Context, view *models.TaskView, removerID int64) error
+}
+
+type RecurringTaskUpdate struct {
+	Title       *string
+	Description *string
+	Priority    *string
+	Labels      *[]string
+	RRule       *string
+	StartsAt    *time.Time
+	Assignees   *[]*models.User
+}
+
+type RecurringTaskService interface {
+	CreateRecurringTask(
+		ctx context.Context,
+		title, description, priority string,
+		labels []string,
+		rule string,
+		startsAt time.Time,
+		creator *models.User,
+		assignees []*models.User,
+		teamID int64,
+	) (*models.RecurringTask, *validator.Validator, error)
+	GetRecurringTaskByID(ctx context.Context, recurringTaskID, teamID int64) (*models.RecurringTask, error)
+	GetAllRecurringTasks(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.RecurringTask, pagination.Metadata, error)
+	UpdateRecurringTask(ctx context.Context, update RecurringTaskUpdate, recurringTask *models.RecurringTask, updaterID int64) (*validator.Validator, error)
+	PauseRecurringTask(ctx context.Context, recurringTask *models.RecurringTask, updaterID int64) error
+	ResumeRecurringTask(ctx context.Context, recurringTask *models.RecurringTask, updaterID int64) error
+	PreviewOccurrences(ctx context.Context, recurringTask *models.RecurringTask, count int) ([]*models.Occurrence, *validator.Validator, error)
+	DeleteRecurringTask(ctx context.Context, recurringTask *models.RecurringTask, removerID int64) error
+	GenerateOccurrences(ctx context.Context, lookahead time.Duration) (int, error)
+}
+
+type TaskTemplateUpdate struct {
+	Name        *string
+	Title       *string
+	Description *string
+	Priority    *string
+	DueOffset   *string
+	Labels      *[]string
+}
+
+type TaskDraft struct {
+	Due         time.Time
+	Title       string
+	Description string
+	Priority    string
+	Labels      []string
+}
+
+type TaskTemplateService interface {
+	CreateTaskTemplate(
+		ctx context.Context,
+		name, title, description, priority, dueOffset string,
+		labels []string,
+		teamID, creatorID int64,
+	) (*models.TaskTemplate, *validator.Validator, error)
+	GetTaskTemplateByID(ctx context.Context, templateID, teamID int64) (*models.TaskTemplate, error)
+	GetAllTaskTemplates(ctx context.Context, teamID int64, paginationOpts pagination.Options) ([]*models.TaskTemplate, pagination.Metadata, error)
+	UpdateTaskTemplate(ctx context.Context, update TaskTemplateUpdate, template *models.TaskTemplate, updaterID int64) (*validator.Validator, error)
+	DeleteTaskTemplate(ctx context.Context, template *models.TaskTemplate, removerID int64) error
+	RenderTaskTemplate(template *models.TaskTemplate, values map[string]string) (*TaskDraft, *validator.Validator, error)
+}
+
+type NotificationService interface {
+	GetNotificationByID(ctx context.Context, notificationID, recipientID int64) (*models.Notification, error)
+	GetAllNotifications(ctx context.Context, filters models.NotificationFilters, paginationOpts pagination.Options, recipientID int64) ([]*models.Notification, pagination.Metadata, error)
+	MarkNotificationAsRead(ctx context.Context, notification *models.Notification) error
 }
 
 type ServiceRegistry struct {
-	UserService  UserService
-	TokenService TokenService
-	TeamService  TeamService
-	TaskService  TaskService
+	UserService          UserService
+	TokenService         TokenService
+	TeamService          TeamService
+	WorkflowService      WorkflowService
+	TaskService          TaskService
+	CommentService       CommentService
+	AttachmentService    AttachmentService
+	TimeEntryService     TimeEntryService
+	ExtensionService     DueDateExtensionService
+	LabelService         LabelService
+	TaskViewService      TaskViewService
+	RecurringTaskService RecurringTaskService
+	TaskTemplateService  TaskTemplateService
+	NotificationService  NotificationService
+	MilestoneService     MilestoneService
+	ProjectService       ProjectService
 }
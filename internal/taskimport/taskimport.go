@@ -0,0 +1,219 @@
+package taskimport
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+const (
+	FormatCSV  = "csv"
+	FormatJSON = "json"
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("taskimport: unsupported format")
+	ErrMalformedFile     = errors.New("taskimport: malformed file")
+	ErrMalformedMapping  = errors.New("taskimport: malformed mapping")
+	ErrUnknownField      = errors.New("taskimport: unknown field")
+)
+
+var Fields = []string{
+	"title",
+	"description",
+	"due",
+	"priority",
+	"assignee_usernames",
+	"labels",
+	"estimate",
+	"estimate_unit",
+	"parent_id",
+	"milestone_id",
+	"project_id",
+}
+
+type Record map[string]string
+
+func (r Record) List(field string) []string {
+	var values []string
+
+	for _, value := range strings.Split(r[field], ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+func ParseMapping(mapping string) (map[string]string, error) {
+	columns := make(map[string]string)
+
+	if strings.TrimSpace(mapping) == "" {
+		return columns, nil
+	}
+
+	for _, pair := range strings.Split(mapping, ",") {
+		column, field, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, ErrMalformedMapping
+		}
+
+		field = strings.ToLower(strings.TrimSpace(field))
+		if !slices.Contains(Fields, field) {
+			return nil, fmt.Errorf("%w %q", ErrUnknownField, field)
+		}
+
+		columns[normalizeColumn(column)] = field
+	}
+
+	return columns, nil
+}
+
+func Parse(r io.Reader, format string, columns map[string]string) ([]Record, error) {
+	switch format {
+	case FormatCSV:
+		return parseCSV(r, columns)
+	case FormatJSON:
+		return parseJSON(r, columns)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+}
+
+func parseCSV(r io.Reader, columns map[string]string) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return []Record{}, nil
+		}
+
+		return nil, fmt.Errorf("%w: %w", ErrMalformedFile, err)
+	}
+
+	header[0] = strings.TrimPrefix(header[0], "\uFEFF")
+
+	fields := make([]string, len(header))
+	for i, column := range header {
+		fields[i] = mapColumn(column, columns)
+	}
+
+	records := []Record{}
+
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrMalformedFile, err)
+		}
+
+		record := make(Record)
+
+		for i, value := range row {
+			if fields[i] != "" {
+				record[fields[i]] = strings.TrimSpace(value)
+			}
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func parseJSON(r io.Reader, columns map[string]string) ([]Record, error) {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+
+	var rows []map[string]any
+
+	if err := decoder.Decode(&rows); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrMalformedFile, err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: the body must only contain a single JSON array", ErrMalformedFile)
+	}
+
+	records := make([]Record, 0, len(rows))
+
+	for i, row := range rows {
+		record := make(Record)
+
+		for column, value := range row {
+			field := mapColumn(column, columns)
+			if field == "" {
+				continue
+			}
+
+			formatted, err := formatJSONValue(value)
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d has an invalid value for %q", ErrMalformedFile, i+1, column)
+			}
+
+			record[field] = formatted
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func formatJSONValue(value any) (string, error) {
+	switch value := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return strings.TrimSpace(value), nil
+	case json.Number:
+		return value.String(), nil
+	case []any:
+		var buf bytes.Buffer
+
+		for i, item := range value {
+			str, ok := item.(string)
+			if !ok {
+				return "", ErrMalformedFile
+			}
+
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+
+			buf.WriteString(strings.TrimSpace(str))
+		}
+
+		return buf.String(), nil
+	default:
+		return "", ErrMalformedFile
+	}
+}
+
+func mapColumn(column string, columns map[string]string) string {
+	column = normalizeColumn(column)
+
+	if field, ok := columns[column]; ok {
+		return field
+	}
+
+	if slices.Contains(Fields, column) {
+		return column
+	}
+
+	return ""
+}
+
+func normalizeColumn(column string) string {
+	return strings.ToLower(strings.TrimSpace(column))
+}
@@ -0,0 +1,84 @@
+package tasktemplate
+
+import (
+	"errors"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidDueOffset = errors.New("tasktemplate: invalid due offset")
+
+var placeholderRX = regexp.MustCompile(`\{\{\s*([A-Za-z_][A-Za-z0-9_]*)\s*\}\}`)
+
+type DueOffset struct {
+	Days     int
+	Duration time.Duration
+}
+
+func (o DueOffset) ApplyTo(t time.Time) time.Time {
+	return t.AddDate(0, 0, o.Days).Add(o.Duration)
+}
+
+func (o DueOffset) IsPositive() bool {
+	return o.Days > 0 || o.Duration > 0
+}
+
+func ParseDueOffset(offset string) (DueOffset, error) {
+	var dueOffset DueOffset
+
+	fields := strings.Fields(strings.ToLower(offset))
+	if len(fields) == 0 || len(fields)%2 != 0 {
+		return DueOffset{}, ErrInvalidDueOffset
+	}
+
+	for i := 0; i < len(fields); i += 2 {
+		amount, err := strconv.Atoi(strings.TrimPrefix(fields[i], "+"))
+		if err != nil || amount < 0 {
+			return DueOffset{}, ErrInvalidDueOffset
+		}
+
+		switch strings.TrimSuffix(fields[i+1], "s") {
+		case "minute":
+			dueOffset.Duration += time.Duration(amount) * time.Minute
+		case "hour":
+			dueOffset.Duration += time.Duration(amount) * time.Hour
+		case "day":
+			dueOffset.Days += amount
+		case "week":
+			dueOffset.Days += amount * 7
+		default:
+			return DueOffset{}, ErrInvalidDueOffset
+		}
+	}
+
+	return dueOffset, nil
+}
+
+func Placeholders(texts ...string) []string {
+	var names []string
+
+	for _, text := range texts {
+		for _, match := range placeholderRX.FindAllStringSubmatch(text, -1) {
+			if !slices.Contains(names, match[1]) {
+				names = append(names, match[1])
+			}
+		}
+	}
+
+	return names
+}
+
+func Render(text string, values map[string]string) string {
+	return placeholderRX.ReplaceAllStringFunc(text, func(placeholder string) string {
+		name := placeholderRX.FindStringSubmatch(placeholder)[1]
+
+		if value, ok := values[name]; ok {
+			return value
+		}
+
+		return placeholder
+	})
+}
@@ -0,0 +1,88 @@
+package tasktemplate
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseDueOffset(t *testing.T) {
+	tests := []struct {
+		name   string
+		offset string
+		want   DueOffset
+		err    error
+	}{
+		{name: "days", offset: "+3 days", want: DueOffset{Days: 3}},
+		{name: "singular unit", offset: "1 week", want: DueOffset{Days: 7}},
+		{name: "combined units", offset: "+1 week 2 days 4 hours 30 minutes", want: DueOffset{Days: 9, Duration: 4*time.Hour + 30*time.Minute}},
+		{name: "mixed case", offset: "2 Hours", want: DueOffset{Duration: 2 * time.Hour}},
+		{name: "zero", offset: "0 days", want: DueOffset{}},
+		{name: "empty", offset: "", err: ErrInvalidDueOffset},
+		{name: "missing unit", offset: "3", err: ErrInvalidDueOffset},
+		{name: "negative amount", offset: "-3 days", err: ErrInvalidDueOffset},
+		{name: "unknown unit", offset: "3 months", err: ErrInvalidDueOffset},
+		{name: "non numeric amount", offset: "few days", err: ErrInvalidDueOffset},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseDueOffset(tt.offset)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("ParseDueOffset(%q) error = %v, want %v", tt.offset, err, tt.err)
+			}
+
+			if got != tt.want {
+				t.Fatalf("ParseDueOffset(%q) = %+v, want %+v", tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDueOffsetApplyTo(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatalf("loading location: %v", err)
+	}
+
+	offset := DueOffset{Days: 1, Duration: 2 * time.Hour}
+	from := time.Date(2024, time.March, 9, 9, 0, 0, 0, newYork)
+
+	want := time.Date(2024, time.March, 10, 11, 0, 0, 0, newYork)
+	if got := offset.ApplyTo(from); !got.Equal(want) {
+		t.Fatalf("ApplyTo(%v) = %v, want %v", from, got, want)
+	}
+}
+
+func TestPlaceholders(t *testing.T) {
+	got := Placeholders("Deploy {{ version }} to {{env}}", "Notify {{owner}} about {{version}}", "{{ 1invalid }} and {{}}")
+	want := []string{"version", "env", "owner"}
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("Placeholders() = %v, want %v", got, want)
+	}
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name   string
+		text   string
+		values map[string]string
+		want   string
+	}{
+		{name: "replaces placeholders", text: "Deploy {{ version }} to {{env}}", values: map[string]string{"version": "1.2", "env": "prod"}, want: "Deploy 1.2 to prod"},
+		{name: "keeps missing placeholders", text: "Deploy {{version}} to {{env}}", values: map[string]string{"version": "1.2"}, want: "Deploy 1.2 to {{env}}"},
+		{name: "repeated placeholder", text: "{{x}}-{{x}}", values: map[string]string{"x": "a"}, want: "a-a"},
+		{name: "does not expand values", text: "{{a}}", values: map[string]string{"a": "{{b}}", "b": "c"}, want: "{{b}}"},
+		{name: "no placeholders", text: "Plain text", values: nil, want: "Plain text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Render(tt.text, tt.values); got != tt.want {
+				t.Fatalf("Render(%q) = %q, want %q", tt.text, got, tt.want)
+			}
+		})
+	}
+}
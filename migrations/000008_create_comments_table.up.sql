@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS comments (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    updated_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    body text NOT NULL,
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    author_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    parent_id bigint REFERENCES comments ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS comments_task_id_parent_id_idx ON comments (task_id, parent_id);
\ No newline at end of file
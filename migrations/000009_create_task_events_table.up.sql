@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_events (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    actor_id bigint REFERENCES users ON DELETE SET NULL,
+    action integer NOT NULL,
+    field text NOT NULL DEFAULT '',
+    old_value text NOT NULL DEFAULT '',
+    new_value text NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS task_events_task_id_idx ON task_events (task_id);
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_parent_id_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS parent_id;
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS labels (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    color text NOT NULL,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE(team_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS task_labels (
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    label_id bigint NOT NULL REFERENCES labels ON DELETE CASCADE,
+    PRIMARY KEY (task_id, label_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_labels_label_id_idx ON task_labels (label_id);
\ No newline at end of file
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS task_views;
\ No newline at end of file
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS task_views (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    is_shared bool NOT NULL DEFAULT false,
+    filters jsonb NOT NULL DEFAULT '{}',
+    sort text NOT NULL DEFAULT '',
+    owner_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE(team_id, owner_id, name)
+);
\ No newline at end of file
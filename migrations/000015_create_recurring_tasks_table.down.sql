@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS recurring_task_assignees;
+
+DROP TABLE IF EXISTS recurring_tasks;
\ No newline at end of file
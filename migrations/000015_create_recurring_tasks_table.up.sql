@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS recurring_tasks (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    title text NOT NULL,
+    description text NOT NULL,
+    priority integer NOT NULL,
+    labels text[] NOT NULL DEFAULT '{}',
+    rrule text NOT NULL,
+    starts_at timestamp(0) with time zone NOT NULL,
+    is_paused boolean NOT NULL DEFAULT false,
+    next_occurrence_at timestamp(0) with time zone,
+    generated_count integer NOT NULL DEFAULT 0,
+    creator_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS recurring_tasks_next_occurrence_at_idx ON recurring_tasks (next_occurrence_at) WHERE NOT is_paused;
+
+CREATE TABLE IF NOT EXISTS recurring_task_assignees (
+    recurring_task_id bigint NOT NULL REFERENCES recurring_tasks ON DELETE CASCADE,
+    position integer NOT NULL,
+    assignee_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    PRIMARY KEY (recurring_task_id, position)
+);
\ No newline at end of file
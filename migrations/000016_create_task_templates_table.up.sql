@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS task_templates (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    title text NOT NULL,
+    description text NOT NULL,
+    priority integer NOT NULL,
+    due_offset text NOT NULL,
+    labels text[] NOT NULL DEFAULT '{}',
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE(team_id, name)
+);
\ No newline at end of file
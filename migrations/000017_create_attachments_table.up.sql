@@ -0,0 +1,31 @@
+CREATE TABLE IF NOT EXISTS attachments (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    filename text NOT NULL,
+    content_type text NOT NULL,
+    size bigint NOT NULL,
+    storage_key text NOT NULL UNIQUE,
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    uploader_id bigint NOT NULL REFERENCES users ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS attachments_task_id_idx ON attachments (task_id);
+
+CREATE TABLE IF NOT EXISTS attachment_blob_deletions (
+    storage_key text PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW()
+);
+
+CREATE OR REPLACE FUNCTION queue_attachment_blob_deletion() RETURNS trigger AS $$
+BEGIN
+    INSERT INTO attachment_blob_deletions (storage_key)
+    VALUES (OLD.storage_key)
+    ON CONFLICT DO NOTHING;
+
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER attachments_queue_blob_deletion
+AFTER DELETE ON attachments
+FOR EACH ROW EXECUTE FUNCTION queue_attachment_blob_deletion();
\ No newline at end of file
@@ -0,0 +1,17 @@
+CREATE TABLE IF NOT EXISTS time_entries (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    started_at timestamp(0) with time zone NOT NULL,
+    ended_at timestamp(0) with time zone,
+    description text NOT NULL DEFAULT '',
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    user_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    CHECK (ended_at IS NULL OR ended_at >= started_at)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS time_entries_running_user_id_key ON time_entries (user_id) WHERE ended_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS time_entries_task_id_idx ON time_entries (task_id);
+
+CREATE INDEX IF NOT EXISTS time_entries_started_at_idx ON time_entries (started_at);
\ No newline at end of file
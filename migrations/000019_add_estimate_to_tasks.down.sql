@@ -0,0 +1,5 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_estimate_check;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate_unit;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS estimate;
\ No newline at end of file
@@ -0,0 +1,7 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate numeric(8, 2);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS estimate_unit integer;
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_estimate_check CHECK (
+    (estimate IS NULL) = (estimate_unit IS NULL) AND (estimate IS NULL OR estimate > 0)
+);
\ No newline at end of file
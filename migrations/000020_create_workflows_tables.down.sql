@@ -0,0 +1,16 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status integer;
+
+UPDATE tasks
+SET status = CASE workflow_statuses.category WHEN 1 THEN 1 WHEN 2 THEN 2 WHEN 3 THEN 3 ELSE 4 END
+FROM workflow_statuses
+WHERE workflow_statuses.id = tasks.status_id;
+
+ALTER TABLE tasks ALTER COLUMN status SET NOT NULL;
+
+DROP INDEX IF EXISTS tasks_status_id_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS status_id;
+
+DROP TABLE IF EXISTS workflow_transitions;
+
+DROP TABLE IF EXISTS workflow_statuses;
\ No newline at end of file
@@ -0,0 +1,75 @@
+CREATE TABLE IF NOT EXISTS workflow_statuses (
+    id bigserial PRIMARY KEY,
+    name text NOT NULL,
+    category integer NOT NULL,
+    position integer NOT NULL,
+    is_initial boolean NOT NULL DEFAULT false,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    UNIQUE(team_id, name)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS workflow_statuses_initial_team_id_key ON workflow_statuses (team_id) WHERE is_initial;
+
+CREATE TABLE IF NOT EXISTS workflow_transitions (
+    from_status_id bigint NOT NULL REFERENCES workflow_statuses ON DELETE CASCADE,
+    to_status_id bigint NOT NULL REFERENCES workflow_statuses ON DELETE CASCADE,
+    actors integer[] NOT NULL,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    PRIMARY KEY (from_status_id, to_status_id),
+    CHECK (from_status_id != to_status_id)
+);
+
+CREATE INDEX IF NOT EXISTS workflow_transitions_team_id_idx ON workflow_transitions (team_id);
+
+INSERT INTO workflow_statuses (name, category, position, is_initial, team_id)
+SELECT defaults.name, defaults.category, defaults.position, defaults.is_initial, teams.id
+FROM teams
+CROSS JOIN (
+    VALUES
+        ('open', 1, 1, true),
+        ('in-progress', 2, 2, false),
+        ('completed', 3, 3, false),
+        ('cancelled', 4, 4, false)
+) AS defaults (name, category, position, is_initial)
+ON CONFLICT DO NOTHING;
+
+INSERT INTO workflow_transitions (from_status_id, to_status_id, actors, team_id)
+SELECT from_status.id, to_status.id, defaults.actors, from_status.team_id
+FROM (
+    VALUES
+        ('open', 'in-progress', ARRAY[2]),
+        ('in-progress', 'completed', ARRAY[1]),
+        ('open', 'cancelled', ARRAY[1]),
+        ('in-progress', 'cancelled', ARRAY[1])
+) AS defaults (from_name, to_name, actors)
+INNER JOIN workflow_statuses AS from_status ON from_status.name = defaults.from_name
+INNER JOIN workflow_statuses AS to_status ON to_status.name = defaults.to_name AND to_status.team_id = from_status.team_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS status_id bigint REFERENCES workflow_statuses;
+
+UPDATE tasks
+SET status_id = workflow_statuses.id
+FROM workflow_statuses
+WHERE workflow_statuses.team_id = tasks.team_id AND workflow_statuses.position = tasks.status;
+
+ALTER TABLE tasks ALTER COLUMN status_id SET NOT NULL;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS status;
+
+CREATE INDEX IF NOT EXISTS tasks_status_id_idx ON tasks (status_id);
+
+UPDATE task_events SET old_value = 'cancelled' WHERE field = 'status' AND old_value = 'candelled';
+
+UPDATE task_events SET new_value = 'cancelled' WHERE field = 'status' AND new_value = 'candelled';
+
+UPDATE task_views
+SET filters = jsonb_set(
+    filters,
+    '{status}',
+    (
+        SELECT jsonb_agg(CASE WHEN status = 'candelled' THEN 'cancelled' ELSE status END)
+        FROM jsonb_array_elements_text(filters->'status') AS statuses (status)
+    )
+)
+WHERE filters->'status' ? 'candelled';
\ No newline at end of file
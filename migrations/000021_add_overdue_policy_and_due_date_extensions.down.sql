@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS due_date_extensions;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS is_late;
+
+ALTER TABLE teams DROP COLUMN IF EXISTS overdue_policy;
\ No newline at end of file
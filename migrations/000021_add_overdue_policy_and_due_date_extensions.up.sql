@@ -0,0 +1,22 @@
+ALTER TABLE teams ADD COLUMN IF NOT EXISTS overdue_policy integer NOT NULL DEFAULT 1;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS is_late boolean NOT NULL DEFAULT false;
+
+CREATE TABLE IF NOT EXISTS due_date_extensions (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    previous_due timestamp(0) with time zone NOT NULL,
+    proposed_due timestamp(0) with time zone NOT NULL,
+    reason text NOT NULL,
+    status integer NOT NULL DEFAULT 1,
+    decision_note text NOT NULL DEFAULT '',
+    decided_at timestamp(0) with time zone,
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    requester_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    decider_id bigint REFERENCES users ON DELETE SET NULL,
+    version integer NOT NULL DEFAULT 1
+);
+
+CREATE INDEX IF NOT EXISTS due_date_extensions_task_id_idx ON due_date_extensions (task_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS due_date_extensions_pending_task_id_key ON due_date_extensions (task_id) WHERE status = 1;
\ No newline at end of file
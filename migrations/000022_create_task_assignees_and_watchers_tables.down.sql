@@ -0,0 +1,13 @@
+DROP TABLE IF EXISTS task_watchers;
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS assignee_id bigint REFERENCES users ON DELETE CASCADE;
+
+UPDATE tasks
+SET assignee_id = COALESCE(
+    (SELECT min(assignee_id) FROM task_assignees WHERE task_assignees.task_id = tasks.id),
+    tasks.creator_id
+);
+
+ALTER TABLE tasks ALTER COLUMN assignee_id SET NOT NULL;
+
+DROP TABLE IF EXISTS task_assignees;
\ No newline at end of file
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS task_assignees (
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    assignee_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    PRIMARY KEY (task_id, assignee_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_assignees_assignee_id_idx ON task_assignees (assignee_id);
+
+INSERT INTO task_assignees (task_id, assignee_id)
+SELECT id, assignee_id FROM tasks;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS assignee_id;
+
+CREATE TABLE IF NOT EXISTS task_watchers (
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    watcher_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (task_id, watcher_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_watchers_watcher_id_idx ON task_watchers (watcher_id);
\ No newline at end of file
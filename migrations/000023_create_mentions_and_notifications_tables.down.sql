@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS notifications;
+
+DROP TABLE IF EXISTS comment_mentions;
+
+DROP TABLE IF EXISTS task_mentions;
\ No newline at end of file
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS task_mentions (
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    mentioned_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    PRIMARY KEY (task_id, mentioned_id)
+);
+
+CREATE TABLE IF NOT EXISTS comment_mentions (
+    comment_id bigint NOT NULL REFERENCES comments ON DELETE CASCADE,
+    mentioned_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    PRIMARY KEY (comment_id, mentioned_id)
+);
+
+CREATE TABLE IF NOT EXISTS notifications (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    kind integer NOT NULL,
+    recipient_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    actor_id bigint NOT NULL REFERENCES users ON DELETE CASCADE,
+    task_id bigint NOT NULL REFERENCES tasks ON DELETE CASCADE,
+    comment_id bigint REFERENCES comments ON DELETE CASCADE,
+    read_at timestamp(0) with time zone
+);
+
+CREATE INDEX IF NOT EXISTS notifications_recipient_id_idx ON notifications (recipient_id, created_at);
\ No newline at end of file
@@ -0,0 +1,3 @@
+ALTER TABLE tasks DROP CONSTRAINT IF EXISTS tasks_team_id_status_id_rank_key;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS rank;
\ No newline at end of file
@@ -0,0 +1,15 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS rank text COLLATE "C";
+
+UPDATE tasks
+SET rank = ranked.rank
+FROM (
+    SELECT
+        id,
+        lpad(row_number() OVER (PARTITION BY team_id, status_id ORDER BY created_at, id)::text, 10, '0') || 'i' AS rank
+    FROM tasks
+) AS ranked
+WHERE tasks.id = ranked.id;
+
+ALTER TABLE tasks ALTER COLUMN rank SET NOT NULL;
+
+ALTER TABLE tasks ADD CONSTRAINT tasks_team_id_status_id_rank_key UNIQUE (team_id, status_id, rank);
\ No newline at end of file
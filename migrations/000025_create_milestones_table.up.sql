@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS milestones (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    starts_at timestamp(0) with time zone NOT NULL,
+    ends_at timestamp(0) with time zone NOT NULL,
+    state integer NOT NULL DEFAULT 1,
+    closed_at timestamp(0) with time zone,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE(team_id, name)
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS milestone_id bigint REFERENCES milestones ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_milestone_id_idx ON tasks (milestone_id);
\ No newline at end of file
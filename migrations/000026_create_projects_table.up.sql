@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS projects (
+    id bigserial PRIMARY KEY,
+    created_at timestamp(0) with time zone NOT NULL DEFAULT NOW(),
+    name text NOT NULL,
+    description text NOT NULL DEFAULT '',
+    lead_id bigint REFERENCES users ON DELETE SET NULL,
+    is_archived boolean NOT NULL DEFAULT false,
+    team_id bigint NOT NULL REFERENCES teams ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    UNIQUE(team_id, name)
+);
+
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS project_id bigint REFERENCES projects ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS tasks_project_id_idx ON tasks (project_id);
\ No newline at end of file
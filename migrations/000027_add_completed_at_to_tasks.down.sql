@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tasks_team_id_completed_at_idx;
+
+ALTER TABLE tasks DROP COLUMN IF EXISTS completed_at;
\ No newline at end of file
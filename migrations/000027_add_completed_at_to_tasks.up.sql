@@ -0,0 +1,15 @@
+ALTER TABLE tasks ADD COLUMN IF NOT EXISTS completed_at timestamp(0) with time zone;
+
+UPDATE tasks
+SET completed_at = coalesce(
+    (
+        SELECT max(task_events.created_at)
+        FROM task_events
+        WHERE task_events.task_id = tasks.id AND task_events.action = 3 AND task_events.new_value = statuses.name
+    ),
+    tasks.created_at
+)
+FROM workflow_statuses AS statuses
+WHERE statuses.id = tasks.status_id AND statuses.category = 3;
+
+CREATE INDEX IF NOT EXISTS tasks_team_id_completed_at_idx ON tasks (team_id, completed_at) WHERE completed_at IS NOT NULL;
\ No newline at end of file